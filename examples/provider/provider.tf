@@ -8,6 +8,10 @@ terraform {
 
 provider "edc" {
   token = "1234"
+
+  # Detected from the connector when omitted.
+  management_api_version = "v1"
+
   addresses = {
     default    = "http://localhost:29193/api"
     management = "http://localhost:29193/api/v1/data"
@@ -15,4 +19,18 @@ provider "edc" {
     public     = "http://localhost:29193/public"
     control    = "http://localhost:29193/control"
   }
+
+  connectors = {
+    consumer = {
+      # The command runs again whenever the token it printed expires.
+      token_command = ["vault", "kv", "get", "-field=api_key", "secret/edc/consumer"]
+      addresses = {
+        default    = "http://localhost:19191/api"
+        management = "http://localhost:19193/api/v1/data"
+        protocol   = "http://localhost:19194/api/v1/ids"
+        public     = "http://localhost:19291/public"
+        control    = "http://localhost:19192/control"
+      }
+    }
+  }
 }
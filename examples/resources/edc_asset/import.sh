@@ -1 +1,4 @@
 terraform import edc_asset assetId
+
+# Resources managed by a named connector are imported with a "<connector>/<id>" identifier.
+terraform import edc_asset consumer/assetId
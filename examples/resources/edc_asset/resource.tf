@@ -33,9 +33,60 @@ resource "edc_asset" "s3" {
   }
 }
 
+resource "edc_asset" "minio" {
+  asset = {
+    "asset:prop:name" : "Exports stored in MinIO",
+  }
+
+  data = {
+    s3 = {
+      bucket_name       = "exports"
+      region            = "eu-central-1"
+      object_prefix     = "daily/"
+      endpoint_override = "http://minio:9000"
+      # Vault secret holding the credentials of the bucket.
+      key_name = "minio-credentials"
+    }
+  }
+}
+
+resource "edc_asset" "azure" {
+  asset = {
+    "asset:prop:name" : "Daily exports stored in Azure",
+  }
+
+  data = {
+    azure = {
+      account     = "exports"
+      container   = "daily"
+      blob_prefix = "2023/"
+      # Vault secret holding the account key or a SAS token.
+      key_name = "exports-sas-token"
+    }
+  }
+}
+
+resource "edc_asset" "orders" {
+  asset = {
+    "asset:prop:name" : "Order events",
+  }
+
+  data = {
+    kafka = {
+      topic             = "orders"
+      bootstrap_servers = ["kafka-1:9092", "kafka-2:9092"]
+      security_protocol = "SASL_SSL"
+      sasl_mechanism    = "SCRAM-SHA-512"
+      # Vault secret holding the SASL credentials.
+      sasl_key_name = "kafka-credentials"
+    }
+  }
+}
+
 resource "edc_asset" "custom" {
+  asset_id = "customAssetId"
+
   asset = {
-    "asset:prop:id" : "customAssetId",
     "asset:prop:name" : "customAssetName",
     "asset:prop:contenttype" : "application/json",
   }
@@ -52,3 +103,68 @@ resource "edc_asset" "custom" {
     EOF
   }
 }
+
+resource "edc_asset" "archive" {
+  asset = {
+    "asset:prop:name" : "Archived exports",
+  }
+
+  data = {
+    custom_properties = {
+      type     = "Archive"
+      location = "cold-storage"
+    }
+  }
+}
+
+resource "edc_asset" "dataset" {
+  # Prefixes usable in the keys of asset, with the JSON-LD management APIs.
+  context = {
+    dct = "http://purl.org/dc/terms/"
+  }
+
+  asset_id = "datasetAssetId"
+
+  asset = {
+    "edc:name" : "dataset",
+    "dct:type" : "https://w3id.org/idsa/core/Dataset",
+  }
+
+  # Only visible to this connector, never published in its catalog.
+  private_properties = {
+    "edc:costCenter" : "data-platform",
+  }
+
+  data = {
+    http = {
+      base_url = "https://example.com/dataset"
+    }
+  }
+}
+
+resource "edc_asset" "typed" {
+  # Properties holding numbers, arrays or nested objects.
+  asset_id = "typedAssetId"
+
+  properties_json = jsonencode({
+    "asset:prop:name" : "typed",
+    "asset:prop:version" : 2,
+    "asset:prop:keywords" : ["weather", "forecast"],
+  })
+
+  data = {
+    http = {
+      base_url   = "https://example.com/forecast"
+      proxy_path = true
+      headers = {
+        "X-Tenant" = "weather"
+      }
+
+      oauth2 = {
+        token_url              = "https://example.com/oauth2/token"
+        client_id              = "connector"
+        client_secret_key_name = "forecast-client-secret"
+      }
+    }
+  }
+}
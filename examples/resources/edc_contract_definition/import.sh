@@ -1 +1,4 @@
 terraform import edc_contract_definition contractId
+
+# Resources managed by a named connector are imported with a "<connector>/<id>" identifier.
+terraform import edc_contract_definition consumer/contractId
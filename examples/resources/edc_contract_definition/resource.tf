@@ -62,4 +62,27 @@ resource "edc_contract_definition" "name" {
       operand_right = edc_asset.s3.id
     }
   ]
+  # Check when planning that the policies and assets exist on the connector.
+  check_references = true
+}
+
+resource "edc_contract_definition" "several_assets" {
+  access_policy_id   = edc_policy.policy.id
+  contract_policy_id = edc_policy.policy.id
+  validity           = 600
+  criteria = [
+    {
+      operand_left       = "asset:prop:id"
+      operator           = "in"
+      operand_right_list = [edc_asset.s3.id, "other-asset"]
+    }
+  ]
+}
+
+resource "edc_contract_definition" "fixed_assets" {
+  access_policy_id   = edc_policy.policy.id
+  contract_policy_id = edc_policy.policy.id
+  validity           = 600
+  # Shorthand for an "asset:prop:id" criterion with the "in" operator.
+  asset_ids = [edc_asset.s3.id, "other-asset"]
 }
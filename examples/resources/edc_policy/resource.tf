@@ -21,6 +21,7 @@ resource "edc_policy" "pol" {
   id = "abcdPolicy"
   policy = {
     uid = "231802-bb34-11ec-8422-0242ac120002",
+    type = "offer"
     permissions = [
       {
         edctype = "dataspaceconnector:permission",
@@ -31,4 +32,62 @@ resource "edc_policy" "pol" {
       }
     ]
   }
+
+  # Fail the deletion, and replacements, of the policy.
+  deletion_protection = true
+}
+
+resource "edc_policy" "disposable" {
+  id = "disposablePolicy"
+  policy = {
+    permissions = [
+      {
+        edctype = "dataspaceconnector:permission",
+        action = {
+          type = "USE"
+        },
+      }
+    ]
+  }
+  # Delete the contract definitions using the policy along with it.
+  force_delete = true
+}
+
+resource "edc_policy" "obligations" {
+  id = "obligationsPolicy"
+  policy = {
+    obligations = [
+      {
+        uid = "notify",
+        action = {
+          type = "NOTIFY"
+        },
+        # Consequences nest to any depth, with the keys of the connector API.
+        consequence = jsonencode({
+          uid    = "compensate"
+          action = { type = "COMPENSATE" }
+          consequence = {
+            uid    = "delete"
+            action = { type = "DELETE" }
+          }
+        })
+      }
+    ]
+    prohibitions = [
+      {
+        target = "assetId",
+        action = {
+          type = "DISTRIBUTE"
+        },
+        # Duties to fulfil when the prohibition is violated.
+        remedies = [
+          {
+            action = {
+              type = "DELETE"
+            },
+          }
+        ]
+      }
+    ]
+  }
 }
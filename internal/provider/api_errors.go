@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// apiViolation is one of the violations returned by the management API in
+// the body of an error response.
+type apiViolation struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+	Path    string `json:"path"`
+	// InvalidValue is kept as sent, as it may be of any JSON type.
+	InvalidValue json.RawMessage `json:"invalidValue"`
+}
+
+// apiErrorResponse is the body of the error responses of the management API,
+// when it is called without the EDC client.
+type apiErrorResponse []apiViolation
+
+func (r apiErrorResponse) Error() string {
+	messages := make([]string, len(r))
+	for i, violation := range r {
+		messages[i] = violation.Message
+		if violation.Path != "" {
+			messages[i] = violation.Path + ": " + messages[i]
+		}
+	}
+	return strings.Join(messages, "; ")
+}
+
+// apiViolations extracts the violations carried by an error of the EDC
+// client. The client wraps them in an unexported slice type, so every error
+// of the chain that is a slice is decoded through its JSON encoding.
+func apiViolations(err error) []apiViolation {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if reflect.TypeOf(err).Kind() != reflect.Slice {
+			continue
+		}
+
+		content, marshalErr := json.Marshal(err)
+		if marshalErr != nil {
+			continue
+		}
+
+		var violations []apiViolation
+		if json.Unmarshal(content, &violations) == nil && len(violations) != 0 {
+			return violations
+		}
+	}
+	return nil
+}
+
+// isNotFoundError reports whether the management API answered that the
+// requested object does not exist.
+func isNotFoundError(err error) bool {
+	for _, violation := range apiViolations(err) {
+		if violation.Type == "ObjectNotFound" {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaTyper is implemented by the resource and data source schemas.
+type schemaTyper interface {
+	TypeAtPath(ctx context.Context, p path.Path) (attr.Type, diag.Diagnostics)
+}
+
+// apiPathAliases maps the dotted paths of management API fields to the
+// attributes holding them when they are not named after the fields.
+type apiPathAliases map[string]path.Path
+
+// addAPIErrorDiagnostics reports an error returned by the EDC client while
+// trying to perform the given action. Each violation returned by the
+// management API is reported on its own, on the attribute it refers to when
+// it can be found in the schema.
+func addAPIErrorDiagnostics(ctx context.Context, diags *diag.Diagnostics, schema schemaTyper, aliases apiPathAliases, action string, err error) {
+	violations := apiViolations(err)
+	if len(violations) == 0 {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to %s, got error: %s", action, err))
+		return
+	}
+
+	for _, violation := range violations {
+		summary := "EDC API Validation Error"
+		if violation.Type != "" {
+			summary += ": " + violation.Type
+		}
+
+		detail := fmt.Sprintf("Unable to %s: %s", action, violation.Message)
+		if violation.Path != "" {
+			detail += "\n\nPath: " + violation.Path
+		}
+		if len(violation.InvalidValue) != 0 && string(violation.InvalidValue) != "null" {
+			detail += "\nInvalid value: " + string(violation.InvalidValue)
+		}
+
+		if attributePath, ok := violation.attributePath(ctx, schema, aliases); ok {
+			diags.AddAttributeError(attributePath, summary, detail)
+		} else {
+			diags.AddError(summary, detail)
+		}
+	}
+}
+
+// apiPathSegment is a field of a management API path, followed by the
+// indices selecting elements of its value.
+type apiPathSegment struct {
+	name    string
+	indices []int
+}
+
+// parseAPIPath splits paths such as "policy.permissions[0].constraints[1]".
+func parseAPIPath(apiPath string) []apiPathSegment {
+	var segments []apiPathSegment
+	for _, part := range strings.Split(apiPath, ".") {
+		segment := apiPathSegment{name: part}
+		if i := strings.Index(part, "["); i >= 0 {
+			segment.name = part[:i]
+			for _, index := range strings.Split(strings.TrimSuffix(part[i+1:], "]"), "][") {
+				if n, err := strconv.Atoi(index); err == nil {
+					segment.indices = append(segment.indices, n)
+				}
+			}
+		}
+		segments = append(segments, segment)
+	}
+	return segments
+}
+
+// attributePath returns the path of the attribute the violation refers to.
+// Leading segments that are not part of the payload, such as the name of the
+// validated method and argument, are skipped, and the path is truncated to
+// the deepest attribute found in the schema.
+func (v apiViolation) attributePath(ctx context.Context, schema schemaTyper, aliases apiPathAliases) (path.Path, bool) {
+	if v.Path == "" {
+		return path.Empty(), false
+	}
+
+	segments := parseAPIPath(v.Path)
+	for start := range segments {
+		if attributePath, ok := resolveAPIPath(ctx, schema, aliases, segments[start:]); ok {
+			return attributePath, true
+		}
+	}
+	return path.Empty(), false
+}
+
+func resolveAPIPath(ctx context.Context, schema schemaTyper, aliases apiPathAliases, segments []apiPathSegment) (path.Path, bool) {
+	current := path.Empty()
+	resolved := false
+
+	for n := len(segments); n > 0; n-- {
+		if alias, ok := aliases[joinAPIPathNames(segments[:n])]; ok {
+			current, resolved = alias, true
+			current, _ = withListIndices(ctx, schema, current, segments[n-1].indices)
+			segments = segments[n:]
+			break
+		}
+	}
+
+	for _, segment := range segments {
+		next, ok := stepAPIPath(ctx, schema, current, segment.name)
+		if !ok {
+			break
+		}
+		current, resolved = next, true
+
+		if current, ok = withListIndices(ctx, schema, current, segment.indices); !ok {
+			break
+		}
+	}
+
+	return current, resolved
+}
+
+func joinAPIPathNames(segments []apiPathSegment) string {
+	names := make([]string, len(segments))
+	for i, segment := range segments {
+		names[i] = segment.name
+	}
+	return strings.Join(names, ".")
+}
+
+// stepAPIPath returns the path of the given field of the attribute at the
+// current path: a key of maps, or the attribute named after the field in
+// snake case otherwise. The singular ODRL terms of the JSON-LD API, such as
+// "permission", also match the plural attributes of the v1 representation.
+func stepAPIPath(ctx context.Context, schema schemaTyper, current path.Path, name string) (path.Path, bool) {
+	currentType, diags := schema.TypeAtPath(ctx, current)
+	if diags.HasError() {
+		return current, false
+	}
+
+	if _, ok := currentType.(basetypes.MapTypable); ok {
+		return current.AtMapKey(name), true
+	}
+
+	attributeName := snakeCase(localName(name))
+	for _, candidate := range []string{attributeName, attributeName + "s"} {
+		next := current.AtName(candidate)
+		if _, diags := schema.TypeAtPath(ctx, next); !diags.HasError() {
+			return next, true
+		}
+	}
+	return current, false
+}
+
+func withListIndices(ctx context.Context, schema schemaTyper, current path.Path, indices []int) (path.Path, bool) {
+	for _, index := range indices {
+		next := current.AtListIndex(index)
+		if _, diags := schema.TypeAtPath(ctx, next); diags.HasError() {
+			return current, false
+		}
+		current = next
+	}
+	return current, true
+}
+
+// localName strips the namespace prefix of compacted JSON-LD terms, such as
+// "edc:", and the namespace of absolute IRIs, up to their last "/" or "#".
+func localName(name string) string {
+	if strings.Contains(name, "://") {
+		return name[strings.LastIndexAny(name, "/#")+1:]
+	}
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// snakeCase converts camel case field names such as "accessPolicyId".
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
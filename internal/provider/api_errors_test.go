@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_addAPIErrorDiagnostics(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		resource      resource.Resource
+		aliases       apiPathAliases
+		body          string
+		expectedPaths []string
+		expectedCount int
+	}{
+		{
+			name:     "nested policy violations",
+			resource: NewPoliciesResource(),
+			body: `[
+				{"message": "must not be null", "type": "NotNull", "path": "createPolicy.arg0.policy.permissions[0].constraints[1].leftExpression", "invalidValue": "null"},
+				{"message": "must not be blank", "type": "NotBlank", "path": "policy.permissions[1].action.type", "invalidValue": ""},
+				{"message": "must be positive", "type": "Positive", "path": "policy.permissions[2].constraints[0]", "invalidValue": -1}
+			]`,
+			expectedPaths: []string{"policy.permissions[0].constraints[1]", "policy.permissions[1].action.type", "policy.permissions[2].constraints[0]"},
+			expectedCount: 3,
+		},
+		{
+			name:          "contract definition field",
+			resource:      NewContractDefinitionResource(),
+			body:          `[{"message": "must be greater than 0", "type": "Positive", "path": "contractDefinition.criteria[0].operandLeft"}]`,
+			expectedPaths: []string{"criteria[0].operand_left"},
+			expectedCount: 1,
+		},
+		{
+			name:          "asset property",
+			resource:      NewAssetsResource(),
+			aliases:       assetAPIPathAliases,
+			body:          `[{"message": "duplicate id", "type": "ObjectConflict", "path": "asset.properties.asset:prop:id"}]`,
+			expectedPaths: []string{`asset["asset:prop:id"]`},
+			expectedCount: 1,
+		},
+		{
+			name:          "violation without path",
+			resource:      NewPoliciesResource(),
+			body:          `[{"message": "Object of type PolicyDefinition with ID=1 was not found", "type": "ObjectNotFound"}]`,
+			expectedCount: 1,
+		},
+		{
+			name:          "unstructured body",
+			resource:      NewPoliciesResource(),
+			body:          `Internal Server Error`,
+			expectedCount: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := newTestConnector(t, server.URL, managementAPIV1).Policies.CreatePolicy(policyInput{})
+			assert.Error(t, err)
+
+			schemaResp := &resource.SchemaResponse{}
+			tt.resource.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+			var diags diag.Diagnostics
+			addAPIErrorDiagnostics(ctx, &diags, schemaResp.Schema, tt.aliases, "create Policy", err)
+
+			assert.Len(t, diags.Errors(), tt.expectedCount)
+			assert.ElementsMatch(t, tt.expectedPaths, getErrorPaths(diags.Errors()))
+		})
+	}
+}
+
+func Test_apiViolations(t *testing.T) {
+	assert.Nil(t, apiViolations(errors.New("failed")))
+	assert.Nil(t, apiViolations(nil))
+}
+
+func Test_snakeCase(t *testing.T) {
+	assert.Equal(t, "access_policy_id", snakeCase("accessPolicyId"))
+	assert.Equal(t, "validity", snakeCase("validity"))
+	assert.Equal(t, "name", snakeCase(localName("edc:name")))
+}
+
+func Test_localName(t *testing.T) {
+	assert.Equal(t, "name", localName("edc:name"))
+	assert.Equal(t, "name", localName("name"))
+	assert.Equal(t, "accessPolicyId", localName("https://w3id.org/edc/v0.0.1/ns/accessPolicyId"))
+	assert.Equal(t, "action", localName("http://www.w3.org/ns/odrl/2/action"))
+	assert.Equal(t, "type", localName("http://www.w3.org/1999/02/22-rdf-syntax-ns#type"))
+}
@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/Think-iT-Labs/edc-connector-client-go/edc"
-	"github.com/Think-iT-Labs/edc-connector-client-go/service/assets"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -21,15 +19,19 @@ func NewAssetDataSource() datasource.DataSource {
 
 // AssetDataSource defines the data source implementation.
 type AssetDataSource struct {
-	client *assets.Client
+	connectors *EDCConnectors
 }
 
 // AssetDataSourceModel describes the data source data model.
 type AssetDataSourceModel struct {
-	Id              types.String `tfsdk:"id"`
-	AssetProperties `tfsdk:"asset_properties"`
-	CreatedAt       types.Int64     `tfsdk:"created_at"`
-	DataAddress     AssetProperties `tfsdk:"data_address"`
+	Id                types.String `tfsdk:"id"`
+	AssetProperties   `tfsdk:"asset_properties"`
+	PropertiesJSON    jsonStringValue `tfsdk:"properties_json"`
+	PrivateProperties AssetProperties `tfsdk:"private_properties"`
+	CreatedAt         types.Int64     `tfsdk:"created_at"`
+	DataAddress       AssetProperties `tfsdk:"data_address"`
+	Connector         types.String    `tfsdk:"connector"`
+	Context           types.Map       `tfsdk:"context"`
 }
 
 func (d *AssetDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -50,6 +52,16 @@ func (d *AssetDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				Computed:    true,
 				ElementType: types.StringType,
 			},
+			"properties_json": schema.StringAttribute{
+				Computed:            true,
+				CustomType:          jsonStringType{},
+				MarkdownDescription: "Asset properties as a JSON object, preserving the types of their values, which `asset_properties` encodes as JSON strings when they are not strings.",
+			},
+			"private_properties": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Private asset properties, only visible to the connector owning the asset. Null with the v1 management API.",
+			},
 			"created_at": schema.Int64Attribute{
 				Computed: true,
 			},
@@ -57,6 +69,14 @@ func (d *AssetDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				Computed:    true,
 				ElementType: types.StringType,
 			},
+			"connector": connectorDataSourceAttribute(),
+			"context": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "JSON-LD prefixes, mapped to the namespace IRI they stand for, used to compact the keys of `asset_properties`. " +
+					"Completes, or overrides, the context of the provider. Keys of the EDC namespace are read with the `asset:prop:` prefix.",
+				Validators: contextValidators(),
+			},
 		},
 	}
 }
@@ -67,26 +87,17 @@ func (d *AssetDataSource) Configure(ctx context.Context, req datasource.Configur
 		return
 	}
 
-	cfg, ok := req.ProviderData.(*edc.Config)
+	connectors, ok := req.ProviderData.(*EDCConnectors)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *edc.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *EDCConnectors, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	client, err := assets.New(*cfg)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to initiate assets client",
-			fmt.Sprintf("Client Error: %v", err),
-		)
-		return
-	}
-
-	d.client = client
+	d.connectors = connectors
 }
 
 func (d *AssetDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -99,24 +110,49 @@ func (d *AssetDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	asset, err := d.client.GetAsset(data.Id.ValueString())
+	connector, diags := d.connectors.Connector(ctx, data.Connector)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	asset, err := connector.Assets.GetAsset(data.Id.ValueString())
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Asset, got error: %s", err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.Config.Schema, nil, "read Asset", err)
 		return
 	}
 
-	assetProperties, err := d.client.GetAssetDataAddress(data.Id.ValueString())
+	assetProperties, err := connector.Assets.GetAssetDataAddress(data.Id.ValueString())
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Asset Data Address, got error: %s", err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.Config.Schema, nil, "read Asset Data Address", err)
+		return
+	}
+
+	prefixes := connector.contextPrefixes(ctx, data.Context, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	tflog.Info(ctx, "read a data source")
 	// For the purposes of this Asset code, hardcoding a response value to
 	// save into the Terraform state.
-	data.AssetProperties = AssetProperties(asset.AssetProperties)
+	properties, privateProperties := asset.Properties, asset.PrivateProperties
+	if connector.usesJSONLD() {
+		properties = prefixes.normalizeKeys(properties, nil)
+		if privateProperties != nil {
+			privateProperties = prefixes.normalizeKeys(privateProperties, nil)
+		}
+	}
+	data.AssetProperties = stringProperties(properties)
+	data.PrivateProperties = stringProperties(privateProperties)
+	if data.PropertiesJSON, err = newJSONStringValue(properties); err != nil {
+		resp.Diagnostics.AddError("Invalid Asset Properties", err.Error())
+		return
+	}
 	data.CreatedAt = types.Int64Value(asset.CreatedAt)
 	data.DataAddress = AssetProperties(assetProperties.AssetProperties)
 
@@ -2,16 +2,25 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 
-	"github.com/Think-iT-Labs/edc-connector-client-go/edc"
-	"github.com/Think-iT-Labs/edc-connector-client-go/service/assets"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -19,6 +28,10 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &AssetsResource{}
 var _ resource.ResourceWithImportState = &AssetsResource{}
+var _ resource.ResourceWithValidateConfig = &AssetsResource{}
+var _ resource.ResourceWithConfigValidators = &AssetsResource{}
+var _ resource.ResourceWithUpgradeState = &AssetsResource{}
+var _ resource.ResourceWithModifyPlan = &AssetsResource{}
 
 func NewAssetsResource() resource.Resource {
 	return &AssetsResource{}
@@ -26,23 +39,59 @@ func NewAssetsResource() resource.Resource {
 
 // AssetsResource defines the resource implementation.
 type AssetsResource struct {
-	client *assets.Client
+	connectors *EDCConnectors
 }
 
 // AssetsResourceModel describes the resource data model.
 type AssetsResourceModel struct {
-	AssetProperties `tfsdk:"asset"`
-	DataAddress     `tfsdk:"data"`
-	Id              types.String `tfsdk:"id"`
+	AssetProperties    `tfsdk:"asset"`
+	PropertiesJSON     jsonStringValue `tfsdk:"properties_json"`
+	PrivateProperties  AssetProperties `tfsdk:"private_properties"`
+	DataAddress        `tfsdk:"data"`
+	AssetId            types.String `tfsdk:"asset_id"`
+	Id                 types.String `tfsdk:"id"`
+	CreatedAt          types.Int64  `tfsdk:"created_at"`
+	Connector          types.String `tfsdk:"connector"`
+	Context            types.Map    `tfsdk:"context"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	ForceDelete        types.Bool   `tfsdk:"force_delete"`
 }
 
 type AssetProperties map[string]string
 
+var (
+	// httpURLPattern matches the endpoints configured in data addresses.
+	httpURLPattern = regexp.MustCompile(`^https?://\S+$`)
+	// httpHeaderPattern matches the names of HTTP headers.
+	httpHeaderPattern = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+	// kafkaTopicPattern matches the names of Kafka topics.
+	kafkaTopicPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,249}$`)
+	// hostPortPattern matches the addresses of Kafka brokers.
+	hostPortPattern = regexp.MustCompile(`^[^\s:,]+:[0-9]{1,5}$`)
+)
+
+// httpProxyFlags are the HttpData attributes telling the connector to forward
+// a part of the consumer request to the backend.
+var httpProxyFlags = []string{"proxy_body", "proxy_path", "proxy_query_params", "proxy_method"}
+
+// assetAPIPathAliases locates the fields of the asset creation payload.
+var assetAPIPathAliases = apiPathAliases{
+	"asset":                  path.Root("asset"),
+	"asset.properties":       path.Root("asset"),
+	"asset.id":               path.Root("asset_id"),
+	"dataAddress":            path.Root("data"),
+	"dataAddress.properties": path.Root("data"),
+}
+
 type DataAddress struct {
 	HttpDataAddress         *HttpDataAddress         `tfsdk:"http"`
 	S3StorageDataAddress    *S3StorageDataAddress    `tfsdk:"s3"`
 	AzureStorageDataAddress *AzureStorageDataAddress `tfsdk:"azure"`
-	CustomDataAddress       types.String             `tfsdk:"custom"`
+	KafkaDataAddress        *KafkaDataAddress        `tfsdk:"kafka"`
+	GCSDataAddress          *GCSDataAddress          `tfsdk:"gcs"`
+	SQLDataAddress          *SQLDataAddress          `tfsdk:"sql"`
+	CustomDataAddress       jsonStringValue          `tfsdk:"custom"`
+	CustomProperties        types.Map                `tfsdk:"custom_properties"`
 }
 
 type HttpDataAddress struct {
@@ -53,24 +102,63 @@ type HttpDataAddress struct {
 	AuthKey          types.String `tfsdk:"auth_key"`
 	AuthCode         types.String `tfsdk:"auth_code"`
 	SecretName       types.String `tfsdk:"secret_name"`
-	ProxyBody        types.String `tfsdk:"proxy_body"`
-	ProxyPath        types.String `tfsdk:"proxy_path"`
-	ProxyQueryParams types.String `tfsdk:"proxy_query_params"`
-	ProxyMethod      types.String `tfsdk:"proxy_method"`
+	ProxyBody        types.Bool   `tfsdk:"proxy_body"`
+	ProxyPath        types.Bool   `tfsdk:"proxy_path"`
+	ProxyQueryParams types.Bool   `tfsdk:"proxy_query_params"`
+	ProxyMethod      types.Bool   `tfsdk:"proxy_method"`
 	ContentType      types.String `tfsdk:"content_type"`
+	Headers          types.Map    `tfsdk:"headers"`
+	OAuth2           *HttpOAuth2  `tfsdk:"oauth2"`
+}
+
+type HttpOAuth2 struct {
+	TokenUrl            types.String `tfsdk:"token_url"`
+	ClientId            types.String `tfsdk:"client_id"`
+	ClientSecretKeyName types.String `tfsdk:"client_secret_key_name"`
+	PrivateKeyName      types.String `tfsdk:"private_key_name"`
+	Scope               types.String `tfsdk:"scope"`
 }
 
 type S3StorageDataAddress struct {
-	Name            types.String `tfsdk:"name"`
-	BucketName      types.String `tfsdk:"bucket_name"`
-	AccessKeyId     types.String `tfsdk:"access_key_id"`
-	SecretAccessKey types.String `tfsdk:"secret_access_key"`
+	Name             types.String `tfsdk:"name"`
+	BucketName       types.String `tfsdk:"bucket_name"`
+	Region           types.String `tfsdk:"region"`
+	ObjectName       types.String `tfsdk:"object_name"`
+	ObjectPrefix     types.String `tfsdk:"object_prefix"`
+	FolderName       types.String `tfsdk:"folder_name"`
+	EndpointOverride types.String `tfsdk:"endpoint_override"`
+	KeyName          types.String `tfsdk:"key_name"`
+	AccessKeyId      types.String `tfsdk:"access_key_id"`
+	SecretAccessKey  types.String `tfsdk:"secret_access_key"`
+}
+
+type KafkaDataAddress struct {
+	Topic            types.String `tfsdk:"topic"`
+	BootstrapServers types.List   `tfsdk:"bootstrap_servers"`
+	SecurityProtocol types.String `tfsdk:"security_protocol"`
+	SaslMechanism    types.String `tfsdk:"sasl_mechanism"`
+	SaslKeyName      types.String `tfsdk:"sasl_key_name"`
+}
+
+type GCSDataAddress struct {
+	BucketName            types.String `tfsdk:"bucket_name"`
+	BlobName              types.String `tfsdk:"blob_name"`
+	ProjectId             types.String `tfsdk:"project_id"`
+	ServiceAccountKeyName types.String `tfsdk:"service_account_key_name"`
+}
+
+type SQLDataAddress struct {
+	DataSourceName types.String `tfsdk:"datasource_name"`
+	Query          types.String `tfsdk:"query"`
 }
 
 type AzureStorageDataAddress struct {
-	Container types.String `tfsdk:"container"`
-	Account   types.String `tfsdk:"account"`
-	BlobName  types.String `tfsdk:"blob_name"`
+	Container  types.String `tfsdk:"container"`
+	Account    types.String `tfsdk:"account"`
+	BlobName   types.String `tfsdk:"blob_name"`
+	BlobPrefix types.String `tfsdk:"blob_prefix"`
+	FolderName types.String `tfsdk:"folder_name"`
+	KeyName    types.String `tfsdk:"key_name"`
 }
 
 func (r *AssetsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -81,10 +169,43 @@ func (r *AssetsResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Assets resource",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
-			"asset": AssetsSchema(),
-			"data":  DataAssetsSchema(),
+			"asset":           AssetsSchema(),
+			"properties_json": PropertiesJSONSchema(),
+			"private_properties": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "Private asset properties, only visible to the connector owning the asset and never published in its catalog, " +
+					"such as internal routing or billing metadata. Requires a JSON-LD management API, `v2` or `v3`. Changing them replaces the asset.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"data":                DataAssetsSchema(),
+			"connector":           connectorResourceAttribute(),
+			"deletion_protection": deletionProtectionAttribute(),
+			"force_delete":        assetForceDeleteAttribute(),
+			"context": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: contextDescription + " Completes, or overrides, the context of the provider.",
+				Validators:          contextValidators(),
+			},
+			"asset_id": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Identifier of the asset, generated by the connector when not set. " +
+					"Takes the place of the `asset:prop:id` property, which must hold the same value when both are set. Changing it replaces the asset.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Assets identifier",
@@ -92,52 +213,150 @@ func (r *AssetsResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"created_at": createdAtAttribute(),
 		},
 	}
 }
 
+// assetForceDeleteAttribute returns the schema of the force_delete attribute
+// of assets.
+func assetForceDeleteAttribute() schema.BoolAttribute {
+	attribute := forceDeleteAttribute("asset", "selecting it by its id")
+	attribute.MarkdownDescription += " Contract agreements granting access to the asset always prevent its deletion."
+	return attribute
+}
+
 // AssetsSchema returns the schema to use for tags.
 func AssetsSchema() *schema.MapAttribute {
 	return &schema.MapAttribute{
-		Required:    true,
-		ElementType: types.StringType,
+		Optional:            true,
+		ElementType:         types.StringType,
+		MarkdownDescription: "Asset properties with string values. Exactly one of `asset` and `properties_json` must be set. Changing them replaces the asset.",
+		PlanModifiers: []planmodifier.Map{
+			mapplanmodifier.RequiresReplace(),
+		},
+		Validators: []validator.Map{
+			mapvalidator.ExactlyOneOf(path.MatchRoot("properties_json")),
+		},
+	}
+}
+
+// PropertiesJSONSchema returns the schema of the asset properties holding
+// any JSON value.
+func PropertiesJSONSchema() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:   true,
+		CustomType: jsonStringType{},
+		MarkdownDescription: "Asset properties as a JSON object, for properties holding numbers, booleans, arrays or nested objects, " +
+			"usually set with `jsonencode`. The types of the values are preserved, and documents differing only by their formatting are equal. " +
+			"Changing them replaces the asset.",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+		Validators: []validator.String{
+			jsonObjectValidator{},
+		},
 	}
 }
 
 // DataAssetsSchema returns the schema to use fo tags.
 func DataAssetsSchema() schema.SingleNestedAttribute {
 	return schema.SingleNestedAttribute{
-		Optional: true,
+		Required: true,
+		MarkdownDescription: "Data address of the asset. Exactly one of `s3`, `http`, `azure`, `kafka`, `gcs`, `sql`, `custom` and `custom_properties` must be set. " +
+			"Changing it replaces the asset.",
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
 		Attributes: map[string]schema.Attribute{
 			"s3":     S3Schema(),
 			"http":   HTTPSchema(),
 			"azure":  AzureSchema(),
+			"kafka":  KafkaSchema(),
+			"gcs":    GCSSchema(),
+			"sql":    SQLSchema(),
 			"custom": CustomSchema(),
+			"custom_properties": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "Properties of a data address of another type, which must be set by the `type` property. " +
+					"Alternative to `custom` for the data addresses whose properties are all strings.",
+			},
 		},
 	}
 }
 
 func CustomSchema() schema.StringAttribute {
 	return schema.StringAttribute{
-		Optional: true,
+		Optional:   true,
+		CustomType: jsonStringType{},
+		MarkdownDescription: "JSON object holding the properties of a data address of another type. " +
+			"Changes of formatting or of the order of the keys are not changes of the data address.",
+		Validators: []validator.String{
+			jsonObjectValidator{},
+		},
 	}
 }
 
 func S3Schema() schema.SingleNestedAttribute {
 	return schema.SingleNestedAttribute{
-		Optional: true,
+		Optional:            true,
+		MarkdownDescription: "Amazon S3, or S3 compatible, storage.",
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Optional: true,
 			},
 			"bucket_name": schema.StringAttribute{
-				Optional: true,
+				Required: true,
+			},
+			"region": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Region of the bucket, such as `eu-central-1`.",
+			},
+			"object_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Key of the object holding the asset.",
+			},
+			"object_prefix": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Prefix of the keys of the objects holding the asset.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("object_name")),
+				},
+			},
+			"folder_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Folder of the bucket holding the asset.",
+			},
+			"endpoint_override": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Endpoint of an S3 compatible store, such as MinIO or Ceph, used in place of the AWS endpoint of the region.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(httpURLPattern, "must be an HTTP or HTTPS URL"),
+				},
+			},
+			"key_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Name of the vault secret holding the credentials of the bucket, used in place of inline keys.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(
+						path.MatchRelative().AtParent().AtName("access_key_id"),
+						path.MatchRelative().AtParent().AtName("secret_access_key"),
+					),
+				},
 			},
 			"access_key_id": schema.StringAttribute{
 				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("secret_access_key")),
+				},
 			},
 			"secret_access_key": schema.StringAttribute{
-				Optional: true,
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("access_key_id")),
+				},
 			},
 		},
 	}
@@ -148,14 +367,115 @@ func AzureSchema() schema.SingleNestedAttribute {
 		Optional: true,
 		Attributes: map[string]schema.Attribute{
 			"container": schema.StringAttribute{
-				Optional: true,
+				Required: true,
 			},
 			"account": schema.StringAttribute{
+				Required: true,
+			},
+			"blob_name": schema.StringAttribute{
 				Optional: true,
 			},
+			"blob_prefix": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Prefix of the names of the blobs holding the asset, for assets made of several files.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("blob_name")),
+				},
+			},
+			"folder_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Folder of the container holding the asset.",
+			},
+			"key_name": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Name of the vault secret holding the account key or a SAS token of the container. " +
+					"The secret itself is never sent by the provider nor stored in the state.",
+			},
+		},
+	}
+}
+
+func KafkaSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: "Kafka topic, for streaming assets.",
+		Attributes: map[string]schema.Attribute{
+			"topic": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(kafkaTopicPattern, "must be a Kafka topic name"),
+				},
+			},
+			"bootstrap_servers": schema.ListAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Brokers of the cluster, as `host:port`.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(hostPortPattern, "must be a host:port address")),
+				},
+			},
+			"security_protocol": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("PLAINTEXT", "SSL", "SASL_PLAINTEXT", "SASL_SSL"),
+				},
+			},
+			"sasl_mechanism": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512", "OAUTHBEARER"),
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("sasl_key_name")),
+				},
+			},
+			"sasl_key_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Name of the vault secret holding the SASL credentials of the connector.",
+			},
+		},
+	}
+}
+
+func GCSSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: "Google Cloud Storage.",
+		Attributes: map[string]schema.Attribute{
+			"bucket_name": schema.StringAttribute{
+				Required: true,
+			},
 			"blob_name": schema.StringAttribute{
 				Optional: true,
 			},
+			"project_id": schema.StringAttribute{
+				Optional: true,
+			},
+			"service_account_key_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Name of the vault secret holding the key of the service account accessing the bucket.",
+			},
+		},
+	}
+}
+
+func SQLSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: "Query of a database.",
+		Attributes: map[string]schema.Attribute{
+			"datasource_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the datasource configured in the connector.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"query": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -163,6 +483,9 @@ func AzureSchema() schema.SingleNestedAttribute {
 func HTTPSchema() schema.SingleNestedAttribute {
 	return schema.SingleNestedAttribute{
 		Optional: true,
+		Validators: []validator.Object{
+			httpAuthValidator{},
+		},
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Optional: true,
@@ -172,64 +495,265 @@ func HTTPSchema() schema.SingleNestedAttribute {
 			},
 			"method": schema.StringAttribute{
 				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"),
+				},
 			},
 			"base_url": schema.StringAttribute{
-				Optional: true,
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(httpURLPattern, "must be an HTTP or HTTPS URL"),
+				},
 			},
 			"auth_key": schema.StringAttribute{
-				Optional: true,
+				Optional:            true,
+				MarkdownDescription: "Header holding the credential of the backend, such as `Authorization`. Requires `auth_code` or `secret_name`.",
 			},
 			"auth_code": schema.StringAttribute{
-				Optional: true,
+				Optional:  true,
+				Sensitive: true,
 			},
 			"secret_name": schema.StringAttribute{
-				Optional: true,
+				Optional:            true,
+				MarkdownDescription: "Name of the vault secret holding the value of the `auth_key` header.",
 			},
-			"proxy_body": schema.StringAttribute{
-				Optional: true,
+			"proxy_body": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether the body of the consumer request is forwarded to the backend.",
 			},
-			"proxy_path": schema.StringAttribute{
-				Optional: true,
+			"proxy_path": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether the path of the consumer request is appended to `base_url`.",
 			},
-			"proxy_query_params": schema.StringAttribute{
-				Optional: true,
+			"proxy_query_params": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether the query parameters of the consumer request are forwarded to the backend.",
 			},
-			"proxy_method": schema.StringAttribute{
-				Optional: true,
+			"proxy_method": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether the method of the consumer request is used in place of `method`.",
 			},
 			"content_type": schema.StringAttribute{
 				Optional: true,
 			},
+			"headers": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Additional headers sent to the backend, keyed by their name.",
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(httpHeaderPattern, "must be an HTTP header name")),
+				},
+			},
+			"oauth2": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "OAuth2 client credentials the connector uses to fetch an access token for the backend.",
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(
+						path.MatchRelative().AtParent().AtName("auth_code"),
+						path.MatchRelative().AtParent().AtName("secret_name"),
+					),
+				},
+				Attributes: map[string]schema.Attribute{
+					"token_url": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Endpoint issuing the access tokens.",
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(httpURLPattern, "must be an HTTP or HTTPS URL"),
+						},
+					},
+					"client_id": schema.StringAttribute{
+						Required: true,
+					},
+					"client_secret_key_name": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Name of the vault secret holding the client secret.",
+						Validators: []validator.String{
+							stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("private_key_name")),
+						},
+					},
+					"private_key_name": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Name of the vault secret holding the private key signing the client assertion.",
+					},
+					"scope": schema.StringAttribute{
+						Optional: true,
+					},
+				},
+			},
 		},
 	}
 }
 
+// httpAuthValidator validates that the credential of the auth_key header is
+// set when the header is.
+type httpAuthValidator struct{}
+
+var _ validator.Object = httpAuthValidator{}
+
+func (v httpAuthValidator) Description(ctx context.Context) string {
+	return "auth_code or secret_name must be set along with auth_key"
+}
+
+func (v httpAuthValidator) MarkdownDescription(ctx context.Context) string {
+	return "`auth_code` or `secret_name` must be set along with `auth_key`"
+}
+
+func (v httpAuthValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	attributes := req.ConfigValue.Attributes()
+	authKey, _ := attributes["auth_key"].(types.String)
+	authCode, _ := attributes["auth_code"].(types.String)
+	secretName, _ := attributes["secret_name"].(types.String)
+	if authKey.IsNull() || !authCode.IsNull() || !secretName.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path.AtName("auth_key"),
+		"Missing HTTP Credential",
+		"The auth_key header is sent with a credential, which must be set by auth_code or, from the vault, by secret_name.",
+	)
+}
+
+// UpgradeState upgrades the states written before the HttpData proxy flags
+// became booleans.
+func (r *AssetsResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: rawStateUpgrader(upgradeAssetStateV0),
+	}
+}
+
+// upgradeAssetStateV0 converts the HttpData proxy flags of a version 0 state
+// from strings to booleans.
+func upgradeAssetStateV0(state map[string]interface{}) error {
+	data, _ := state["data"].(map[string]interface{})
+	httpData, _ := data["http"].(map[string]interface{})
+	if httpData == nil {
+		return nil
+	}
+
+	for _, flag := range httpProxyFlags {
+		value, _ := httpData[flag].(string)
+		if value == "" {
+			httpData[flag] = nil
+			continue
+		}
+
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("the %s attribute of the HTTP data address must be a boolean, got %q", flag, value)
+		}
+		httpData[flag] = parsed
+	}
+	return nil
+}
+
+// ConfigValidators requires exactly one type of data address, so that the
+// connector is never sent several of them.
+func (r *AssetsResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("data").AtName("s3"),
+			path.MatchRoot("data").AtName("http"),
+			path.MatchRoot("data").AtName("azure"),
+			path.MatchRoot("data").AtName("kafka"),
+			path.MatchRoot("data").AtName("gcs"),
+			path.MatchRoot("data").AtName("sql"),
+			path.MatchRoot("data").AtName("custom"),
+			path.MatchRoot("data").AtName("custom_properties"),
+		),
+	}
+}
+
+// ValidateConfig reports custom data address properties lacking a type, and
+// the asset id properties conflicting with asset_id.
+func (r *AssetsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var assetId types.String
+	var asset, resourceContext, customProperties types.Map
+	var propertiesJSON jsonStringValue
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("data").AtName("custom_properties"), &customProperties)...)
+	if !customProperties.IsNull() && !customProperties.IsUnknown() {
+		if _, ok := customProperties.Elements()["type"]; !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("data").AtName("custom_properties"),
+				"Missing Data Address Type",
+				"The custom properties of a data address must set its type with the \"type\" property.",
+			)
+		}
+	}
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("asset_id"), &assetId)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("asset"), &asset)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("properties_json"), &propertiesJSON)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("context"), &resourceContext)...)
+
+	if resp.Diagnostics.HasError() || assetId.IsNull() || assetId.IsUnknown() {
+		return
+	}
+
+	var prefixes map[string]string
+	if !resourceContext.IsNull() && !resourceContext.IsUnknown() {
+		resp.Diagnostics.Append(resourceContext.ElementsAs(ctx, &prefixes, false)...)
+	}
+	idKeys := defaultJSONLDPrefixes.with(prefixes)
+
+	properties := map[string]attr.Value{}
+	if !asset.IsNull() && !asset.IsUnknown() {
+		properties = asset.Elements()
+	}
+
+	var document map[string]interface{}
+	if !propertiesJSON.IsNull() && !propertiesJSON.IsUnknown() && propertiesJSON.Unmarshal(&document) == nil {
+		for key, value := range document {
+			properties[key] = types.StringValue(stringValue(value))
+		}
+	}
+
+	for key, value := range properties {
+		id, ok := value.(types.String)
+		if !idKeys.isAssetIdKey(key) || !ok || id.IsUnknown() || id.IsNull() || id.ValueString() == assetId.ValueString() {
+			continue
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root("asset_id"),
+			"Conflicting Asset Identifier",
+			fmt.Sprintf("The asset_id attribute is %q while the %q asset property, which also sets the identifier of the asset, is %q. "+
+				"Remove the property, or set it to the same value.", assetId.ValueString(), key, id.ValueString()),
+		)
+	}
+}
+
+// ModifyPlan rejects destroying or replacing the asset when it is protected
+// against deletion, and records the planned asset_id, which contract
+// definitions checking their references may select.
+func (r *AssetsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedDeletionProtection(ctx, req, resp,
+		path.Root("connector"), path.Root("asset_id"), path.Root("asset"), path.Root("properties_json"), path.Root("private_properties"), path.Root("data"))
+	r.connectors.recordPlannedId(ctx, req.Plan, assetReference, path.Root("asset_id"))
+}
+
 func (r *AssetsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	cfg, ok := req.ProviderData.(*edc.Config)
+	connectors, ok := req.ProviderData.(*EDCConnectors)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *edc.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *EDCConnectors, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	client, err := assets.New(*cfg)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to initiate assets client",
-			fmt.Sprintf("Client Error: %v", err),
-		)
-		return
-	}
-
-	r.client = client
+	r.connectors = connectors
 }
 
 func (r *AssetsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -242,6 +766,13 @@ func (r *AssetsResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	connector, diags := r.connectors.Connector(ctx, data.Connector)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	sdkObject, err := data.toSDKObject(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -250,16 +781,42 @@ func (r *AssetsResource) Create(ctx context.Context, req resource.CreateRequest,
 		)
 		return
 	}
-	output, err := r.client.CreateAsset(*sdkObject)
+
+	prefixes := connector.contextPrefixes(ctx, data.Context, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.AssetId.IsNull() && !data.AssetId.IsUnknown() {
+		sdkObject.Properties[legacyAssetPropertyPrefix+"id"] = data.AssetId.ValueString()
+	}
+
+	if connector.usesJSONLD() {
+		sdkObject.Properties = prefixes.expandKeys(sdkObject.Properties)
+		sdkObject.PrivateProperties = prefixes.expandKeys(sdkObject.PrivateProperties)
+	} else if len(sdkObject.PrivateProperties) != 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("private_properties"),
+			"Unsupported Private Properties",
+			"The connector serves the v1 management API, which has no private properties. "+
+				"Private properties require the JSON-LD management APIs, v2 or v3.",
+		)
+		return
+	}
+	output, err := connector.Assets.CreateAsset(*sdkObject)
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create Assets, got error: %s", err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.Plan.Schema, assetAPIPathAliases, "create Assets", err)
 		return
 	}
 
 	// For the purposes of this Assets code, hardcoding a response value to
 	// save into the Terraform state.
+	connector.assetCache.invalidate()
 	data.Id = types.StringValue(output.Id)
+	data.AssetId = types.StringValue(output.Id)
+	data.CreatedAt = types.Int64Value(output.CreatedAt)
 
 	// Write logs using the tflog package
 	tflog.Trace(ctx, "created an asset")
@@ -279,14 +836,74 @@ func (r *AssetsResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	asset, err := r.client.GetAsset(data.Id.ValueString())
+	connector, diags := r.connectors.Connector(ctx, data.Connector)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	asset, err := connector.Assets.GetAsset(data.Id.ValueString())
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Assets, got error: %s", err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.State.Schema, assetAPIPathAliases, "read Assets", err)
 		return
 	}
 
-	data.AssetProperties = AssetProperties(asset.AssetProperties)
+	prefixes := connector.contextPrefixes(ctx, data.Context, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	properties, privateProperties := asset.Properties, asset.PrivateProperties
+
+	prior, err := data.properties()
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("properties_json"), "Invalid Asset Properties", err.Error())
+		return
+	}
+
+	// Keep the keys of the prior state for the properties the connector
+	// returns in another form, such as their expanded IRI.
+	if connector.usesJSONLD() {
+		properties = prefixes.normalizeKeys(properties, prior)
+		privateProperties = prefixes.normalizeKeys(privateProperties, data.PrivateProperties.values())
+	}
+
+	// The connector returns the id of the asset among its properties. It is
+	// only kept when it is set in the properties rather than with asset_id,
+	// or when the asset is imported.
+	if prior != nil && !prefixes.hasAssetId(prior) {
+		for key := range properties {
+			if prefixes.isAssetIdKey(key) {
+				delete(properties, key)
+			}
+		}
+	}
+	data.AssetId = types.StringValue(asset.Id)
+	data.CreatedAt = types.Int64Value(asset.CreatedAt)
+
+	if data.DataAddress.isRefreshed() {
+		dataAddress, err := connector.Assets.GetAssetDataAddress(data.Id.ValueString())
+		if err != nil {
+			addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.State.Schema, assetAPIPathAliases, "read Assets", err)
+			return
+		}
+		data.DataAddress.refresh(dataAddress.AssetProperties)
+	}
+
+	// Keep private properties null when there are none.
+	if len(privateProperties) != 0 || data.PrivateProperties != nil {
+		data.PrivateProperties = stringProperties(privateProperties)
+	}
+
+	if data.PropertiesJSON.IsNull() {
+		data.AssetProperties = stringProperties(properties)
+	} else if data.PropertiesJSON, err = newJSONStringValue(properties); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("properties_json"), "Invalid Asset Properties", err.Error())
+		return
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -312,72 +929,328 @@ func (r *AssetsResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
+	if resp.Diagnostics.HasError() || !checkDeletionProtection(data.DeletionProtection, &resp.Diagnostics) {
+		return
+	}
+
+	connector, diags := r.connectors.Connector(ctx, data.Connector)
+	resp.Diagnostics.Append(diags...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	err := r.client.DeleteAsset(data.Id.ValueString())
+	definitions, agreements, err := assetDeletionBlockers(connector, data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Check Asset References",
+			"The provider could not look up the contract definitions and agreements referencing the asset, which is deleted regardless.\n\nError: "+err.Error(),
+		)
+	} else if !clearDeletionBlockers(ctx, connector, "asset", data.Id.ValueString(), definitions, agreements, data.ForceDelete.ValueBool(), &resp.Diagnostics) {
+		return
+	}
+
+	err = connector.Assets.DeleteAsset(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete Assets, got error: %s", err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.State.Schema, assetAPIPathAliases, "delete Assets", err)
 		return
 	}
+	connector.assetCache.invalidate()
 }
 
 func (r *AssetsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	r.connectors.ImportStatePassthroughID(ctx, req, resp)
+}
+
+// properties returns the asset properties, from either asset or
+// properties_json.
+func (r *AssetsResourceModel) properties() (map[string]interface{}, error) {
+	properties := map[string]interface{}{}
+	if !r.PropertiesJSON.IsNull() && !r.PropertiesJSON.IsUnknown() {
+		if err := r.PropertiesJSON.Unmarshal(&properties); err != nil {
+			return nil, fmt.Errorf("properties_json must be a JSON object: %w", err)
+		}
+		return properties, nil
+	}
+
+	return r.AssetProperties.values(), nil
 }
 
-func (r *AssetsResourceModel) toSDKObject(ctx context.Context) (*assets.CreateAssetInput, error) {
+// values returns the properties as JSON values.
+func (p AssetProperties) values() map[string]interface{} {
+	if p == nil {
+		return nil
+	}
+
+	values := make(map[string]interface{}, len(p))
+	for key, value := range p {
+		values[key] = value
+	}
+	return values
+}
+
+// stringProperties returns asset properties as strings, JSON encoding the
+// values of other types.
+func stringProperties(properties map[string]interface{}) AssetProperties {
+	if properties == nil {
+		return nil
+	}
+
+	values := make(AssetProperties, len(properties))
+	for key, value := range properties {
+		values[key] = stringValue(value)
+	}
+	return values
+}
+
+func (r *AssetsResourceModel) toSDKObject(ctx context.Context) (*assetInput, error) {
+	dataAddress, err := r.DataAddress.properties()
+	if err != nil {
+		return nil, err
+	}
+	// Data addresses may hold credentials, only their type is logged.
 	tflog.Debug(ctx, "transform tf object to sdk object", map[string]interface{}{
-		"tf object": r.DataAddress,
+		"data address type": dataAddress["type"],
 	})
-	dataAddress := assets.DataAddress{}
-
-	if r.DataAddress.HttpDataAddress != nil {
-		dataAddress.HttpDataAddress = &assets.HttpData{
-			Name:             r.DataAddress.HttpDataAddress.Name.ValueStringPointer(),
-			Path:             r.DataAddress.HttpDataAddress.Path.ValueStringPointer(),
-			Method:           r.DataAddress.HttpDataAddress.Method.ValueStringPointer(),
-			BaseUrl:          r.DataAddress.HttpDataAddress.BaseUrl.ValueStringPointer(),
-			AuthKey:          r.DataAddress.HttpDataAddress.AuthKey.ValueStringPointer(),
-			SecretName:       r.DataAddress.HttpDataAddress.SecretName.ValueStringPointer(),
-			AuthCode:         r.DataAddress.HttpDataAddress.AuthCode.ValueStringPointer(),
-			ProxyBody:        r.DataAddress.HttpDataAddress.ProxyBody.ValueStringPointer(),
-			ProxyPath:        r.DataAddress.HttpDataAddress.ProxyPath.ValueStringPointer(),
-			ProxyQueryParams: r.DataAddress.HttpDataAddress.ProxyQueryParams.ValueStringPointer(),
-			ProxyMethod:      r.DataAddress.HttpDataAddress.ProxyMethod.ValueStringPointer(),
-			ContentType:      r.DataAddress.HttpDataAddress.ContentType.ValueStringPointer(),
+
+	properties, err := r.properties()
+	if err != nil {
+		return nil, err
+	}
+
+	return &assetInput{
+		Properties:        properties,
+		PrivateProperties: r.PrivateProperties.values(),
+		DataAddress:       dataAddress,
+	}, nil
+}
+
+// httpHeaderPropertyPrefix prefixes the names of the additional headers in
+// the properties of HttpData addresses.
+const httpHeaderPropertyPrefix = "header:"
+
+// kafkaBootstrapServersProperty holds the comma separated brokers of Kafka
+// data addresses.
+const kafkaBootstrapServersProperty = "kafka.bootstrap.servers"
+
+// dataAddressProperties holds the properties of a data address, keyed by
+// their EDC name.
+type dataAddressProperties map[string]interface{}
+
+// setString sets the property when the attribute is configured.
+func (p dataAddressProperties) setString(key string, value types.String) {
+	if !value.IsNull() && !value.IsUnknown() {
+		p[key] = value.ValueString()
+	}
+}
+
+// setBool sets the property when the attribute is configured. The connector
+// reads flags from their string form.
+func (p dataAddressProperties) setBool(key string, value types.Bool) {
+	if !value.IsNull() && !value.IsUnknown() {
+		p[key] = strconv.FormatBool(value.ValueBool())
+	}
+}
+
+// properties returns the properties of the configured data address,
+// including its type.
+func (d DataAddress) properties() (dataAddressProperties, error) {
+	switch {
+	case d.HttpDataAddress != nil:
+		return d.HttpDataAddress.properties(), nil
+	case d.S3StorageDataAddress != nil:
+		return d.S3StorageDataAddress.properties(), nil
+	case d.AzureStorageDataAddress != nil:
+		return d.AzureStorageDataAddress.properties(), nil
+	case d.KafkaDataAddress != nil:
+		return d.KafkaDataAddress.properties(), nil
+	case d.GCSDataAddress != nil:
+		return d.GCSDataAddress.properties(), nil
+	case d.SQLDataAddress != nil:
+		return d.SQLDataAddress.properties(), nil
+	case d.CustomDataAddress.ValueString() != "":
+		properties := dataAddressProperties{}
+		if err := d.CustomDataAddress.Unmarshal(&properties); err != nil {
+			return nil, err
+		}
+		return properties, nil
+	case !d.CustomProperties.IsNull() && !d.CustomProperties.IsUnknown():
+		properties := dataAddressProperties{}
+		for key, value := range d.CustomProperties.Elements() {
+			if value, ok := value.(types.String); ok {
+				properties.setString(key, value)
+			}
 		}
+		return properties, nil
 	}
+	return nil, fmt.Errorf("unsupported type of asset address")
+}
 
-	if r.DataAddress.S3StorageDataAddress != nil {
-		dataAddress.S3StorageDataAddress = &assets.S3Data{
-			Name:            r.DataAddress.S3StorageDataAddress.Name.ValueStringPointer(),
-			BucketName:      r.DataAddress.S3StorageDataAddress.BucketName.ValueStringPointer(),
-			AccessKeyId:     r.DataAddress.S3StorageDataAddress.AccessKeyId.ValueStringPointer(),
-			SecretAccessKey: r.DataAddress.S3StorageDataAddress.SecretAccessKey.ValueStringPointer(),
+func (d *HttpDataAddress) properties() dataAddressProperties {
+	properties := dataAddressProperties{"type": "HttpData"}
+	properties.setString("name", d.Name)
+	properties.setString("path", d.Path)
+	properties.setString("method", d.Method)
+	properties.setString("baseUrl", d.BaseUrl)
+	properties.setString("authKey", d.AuthKey)
+	properties.setString("authCode", d.AuthCode)
+	properties.setString("secretName", d.SecretName)
+	properties.setBool("proxyBody", d.ProxyBody)
+	properties.setBool("proxyPath", d.ProxyPath)
+	properties.setBool("proxyQueryParams", d.ProxyQueryParams)
+	properties.setBool("proxyMethod", d.ProxyMethod)
+	properties.setString("contentType", d.ContentType)
+	for name, value := range d.Headers.Elements() {
+		if value, ok := value.(types.String); ok {
+			properties.setString(httpHeaderPropertyPrefix+name, value)
 		}
 	}
+	if d.OAuth2 != nil {
+		properties.setString("oauth2:tokenUrl", d.OAuth2.TokenUrl)
+		properties.setString("oauth2:clientId", d.OAuth2.ClientId)
+		properties.setString("oauth2:clientSecretKeyName", d.OAuth2.ClientSecretKeyName)
+		properties.setString("oauth2:privateKeyName", d.OAuth2.PrivateKeyName)
+		properties.setString("oauth2:scope", d.OAuth2.Scope)
+	}
+	return properties
+}
+
+func (d *S3StorageDataAddress) properties() dataAddressProperties {
+	properties := dataAddressProperties{"type": "AmazonS3"}
+	properties.setString("name", d.Name)
+	properties.setString("bucketName", d.BucketName)
+	properties.setString("region", d.Region)
+	properties.setString("objectName", d.ObjectName)
+	properties.setString("objectPrefix", d.ObjectPrefix)
+	properties.setString("folderName", d.FolderName)
+	properties.setString("endpointOverride", d.EndpointOverride)
+	properties.setString("keyName", d.KeyName)
+	properties.setString("accessKeyId", d.AccessKeyId)
+	properties.setString("secretAccessKey", d.SecretAccessKey)
+	return properties
+}
+
+func (d *AzureStorageDataAddress) properties() dataAddressProperties {
+	properties := dataAddressProperties{"type": "AzureStorage"}
+	properties.setString("container", d.Container)
+	properties.setString("account", d.Account)
+	properties.setString("blobname", d.BlobName)
+	properties.setString("blobPrefix", d.BlobPrefix)
+	properties.setString("folderName", d.FolderName)
+	properties.setString("keyName", d.KeyName)
+	return properties
+}
+
+func (d *AzureStorageDataAddress) refresh(properties map[string]string) {
+	refreshStrings(properties, "AzureStorage", map[string]*types.String{
+		"container":  &d.Container,
+		"account":    &d.Account,
+		"blobname":   &d.BlobName,
+		"blobPrefix": &d.BlobPrefix,
+		"folderName": &d.FolderName,
+		"keyName":    &d.KeyName,
+	})
+}
 
-	if r.DataAddress.AzureStorageDataAddress != nil {
-		dataAddress.AzureStorageDataAddress = &assets.AzureData{
-			Container: r.DataAddress.AzureStorageDataAddress.Container.ValueStringPointer(),
-			Account:   r.DataAddress.AzureStorageDataAddress.Account.ValueStringPointer(),
-			BlobName:  r.DataAddress.AzureStorageDataAddress.BlobName.ValueStringPointer(),
+func (d *KafkaDataAddress) properties() dataAddressProperties {
+	properties := dataAddressProperties{"type": "Kafka"}
+	properties.setString("topic", d.Topic)
+	if !d.BootstrapServers.IsNull() && !d.BootstrapServers.IsUnknown() {
+		servers := make([]string, 0, len(d.BootstrapServers.Elements()))
+		for _, server := range d.BootstrapServers.Elements() {
+			if server, ok := server.(types.String); ok {
+				servers = append(servers, server.ValueString())
+			}
 		}
+		properties[kafkaBootstrapServersProperty] = strings.Join(servers, ",")
 	}
+	properties.setString("kafka.security.protocol", d.SecurityProtocol)
+	properties.setString("kafka.sasl.mechanism", d.SaslMechanism)
+	properties.setString("keyName", d.SaslKeyName)
+	return properties
+}
 
-	if r.DataAddress.CustomDataAddress.ValueString() != "" {
-		err := json.Unmarshal([]byte(r.DataAddress.CustomDataAddress.ValueString()), &dataAddress.CustomDataAddress)
-		if err != nil {
-			return nil, err
+func (d *KafkaDataAddress) refresh(properties map[string]string) {
+	refreshStrings(properties, "Kafka", map[string]*types.String{
+		"topic":                   &d.Topic,
+		"kafka.security.protocol": &d.SecurityProtocol,
+		"kafka.sasl.mechanism":    &d.SaslMechanism,
+		"keyName":                 &d.SaslKeyName,
+	})
+	if servers, ok := properties[kafkaBootstrapServersProperty]; ok && properties["type"] == "Kafka" {
+		values := make([]attr.Value, 0)
+		for _, server := range strings.Split(servers, ",") {
+			values = append(values, types.StringValue(strings.TrimSpace(server)))
 		}
+		d.BootstrapServers = types.ListValueMust(types.StringType, values)
 	}
+}
 
-	return &assets.CreateAssetInput{
-		Asset: assets.Asset{
-			AssetProperties: r.AssetProperties,
-		},
-		DataAddress: dataAddress,
-	}, nil
+func (d *GCSDataAddress) properties() dataAddressProperties {
+	properties := dataAddressProperties{"type": "GoogleCloudStorage"}
+	properties.setString("bucket_name", d.BucketName)
+	properties.setString("blob_name", d.BlobName)
+	properties.setString("project_id", d.ProjectId)
+	properties.setString("service_account_key_name", d.ServiceAccountKeyName)
+	return properties
+}
+
+func (d *GCSDataAddress) refresh(properties map[string]string) {
+	refreshStrings(properties, "GoogleCloudStorage", map[string]*types.String{
+		"bucket_name":              &d.BucketName,
+		"blob_name":                &d.BlobName,
+		"project_id":               &d.ProjectId,
+		"service_account_key_name": &d.ServiceAccountKeyName,
+	})
+}
+
+func (d *SQLDataAddress) properties() dataAddressProperties {
+	properties := dataAddressProperties{"type": "Sql"}
+	properties.setString("dataSourceName", d.DataSourceName)
+	properties.setString("query", d.Query)
+	return properties
+}
+
+func (d *SQLDataAddress) refresh(properties map[string]string) {
+	refreshStrings(properties, "Sql", map[string]*types.String{
+		"dataSourceName": &d.DataSourceName,
+		"query":          &d.Query,
+	})
+}
+
+// refresh updates the typed data address with the properties read from the
+// connector. Only the data addresses whose properties the connector returns
+// unchanged are refreshed.
+func (d *DataAddress) refresh(properties map[string]string) {
+	switch {
+	case d.AzureStorageDataAddress != nil:
+		d.AzureStorageDataAddress.refresh(properties)
+	case d.KafkaDataAddress != nil:
+		d.KafkaDataAddress.refresh(properties)
+	case d.GCSDataAddress != nil:
+		d.GCSDataAddress.refresh(properties)
+	case d.SQLDataAddress != nil:
+		d.SQLDataAddress.refresh(properties)
+	}
+}
+
+// isRefreshed reports whether the data address is refreshed from the
+// connector.
+func (d DataAddress) isRefreshed() bool {
+	return d.AzureStorageDataAddress != nil || d.KafkaDataAddress != nil || d.GCSDataAddress != nil || d.SQLDataAddress != nil
+}
+
+// refreshStrings updates the attributes with the properties of a data address
+// of the given type read from the connector. Attributes the connector does not
+// return, as it may redact the references to secrets, keep their prior value.
+func refreshStrings(properties map[string]string, dataAddressType string, attributes map[string]*types.String) {
+	if properties["type"] != dataAddressType {
+		return
+	}
+
+	for key, value := range attributes {
+		if property, ok := properties[key]; ok {
+			*value = types.StringValue(property)
+		}
+	}
 }
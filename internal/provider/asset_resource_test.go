@@ -1,11 +1,20 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccS3AssetResource(t *testing.T) {
@@ -29,6 +38,7 @@ func TestAccS3AssetResource(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "data.s3.bucket_name", "testBucket"),
 					resource.TestCheckResourceAttr(resourceName, "data.s3.access_key_id", "dummy_key"),
 					resource.TestCheckResourceAttr(resourceName, "data.s3.secret_access_key", "dummy_key"),
+					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
 				),
 			},
 		},
@@ -99,3 +109,615 @@ resource "edc_asset" "http" {
 }
 `, assetId, assetName)
 }
+
+func TestAccAssetResource_assetId(t *testing.T) {
+	resourceName := "edc_asset.http"
+	assetId := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAssetIdResourceConfig(assetId),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", assetId),
+					resource.TestCheckResourceAttr(resourceName, "asset_id", assetId),
+					resource.TestCheckResourceAttr(resourceName, "asset.%", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"asset",
+					"data",
+				},
+			},
+		},
+	})
+}
+
+func testAccAssetIdResourceConfig(assetId string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "edc_asset" "http" {
+	asset_id = %[1]q
+	asset = {
+		"asset:prop:name" : "named by asset_id",
+	}
+	data = {
+		http = {
+			base_url = "https://example.com"
+		}
+	}
+}
+`, assetId)
+}
+
+func TestAssetsResource_ValidateConfig(t *testing.T) {
+	stringMap := tftypes.Map{ElementType: tftypes.String}
+
+	tests := []struct {
+		name          string
+		values        map[string]tftypes.Value
+		expectedError bool
+	}{
+		{
+			name: "asset_id alone",
+			values: map[string]tftypes.Value{
+				"asset_id": tftypes.NewValue(tftypes.String, "asset-1"),
+				"asset":    tftypes.NewValue(stringMap, map[string]tftypes.Value{"asset:prop:name": tftypes.NewValue(tftypes.String, "a")}),
+			},
+		},
+		{
+			name: "same id property",
+			values: map[string]tftypes.Value{
+				"asset_id": tftypes.NewValue(tftypes.String, "asset-1"),
+				"asset":    tftypes.NewValue(stringMap, map[string]tftypes.Value{"asset:prop:id": tftypes.NewValue(tftypes.String, "asset-1")}),
+			},
+		},
+		{
+			name: "conflicting id property",
+			values: map[string]tftypes.Value{
+				"asset_id": tftypes.NewValue(tftypes.String, "asset-1"),
+				"asset":    tftypes.NewValue(stringMap, map[string]tftypes.Value{"edc:id": tftypes.NewValue(tftypes.String, "asset-2")}),
+			},
+			expectedError: true,
+		},
+		{
+			name: "conflicting id in properties_json",
+			values: map[string]tftypes.Value{
+				"asset_id":        tftypes.NewValue(tftypes.String, "asset-1"),
+				"properties_json": tftypes.NewValue(tftypes.String, `{"id": "asset-2"}`),
+			},
+			expectedError: true,
+		},
+		{
+			name: "custom properties with type",
+			values: map[string]tftypes.Value{
+				"data": newTestDataAddressValue(map[string]tftypes.Value{
+					"custom_properties": tftypes.NewValue(stringMap, map[string]tftypes.Value{"type": tftypes.NewValue(tftypes.String, "Custom")}),
+				}),
+			},
+		},
+		{
+			name: "custom properties without type",
+			values: map[string]tftypes.Value{
+				"data": newTestDataAddressValue(map[string]tftypes.Value{
+					"custom_properties": tftypes.NewValue(stringMap, map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "Custom")}),
+				}),
+			},
+			expectedError: true,
+		},
+		{
+			name: "unknown id property",
+			values: map[string]tftypes.Value{
+				"asset_id": tftypes.NewValue(tftypes.String, "asset-1"),
+				"asset":    tftypes.NewValue(stringMap, map[string]tftypes.Value{"asset:prop:id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue)}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &AssetsResource{}
+			resp := &fwresource.ValidateConfigResponse{}
+			r.ValidateConfig(context.Background(), fwresource.ValidateConfigRequest{
+				Config: newTestResourceConfig(r, tt.values),
+			}, resp)
+
+			assert.Equal(t, tt.expectedError, resp.Diagnostics.HasError(), resp.Diagnostics)
+		})
+	}
+}
+
+// newTestDataAddressValue returns a data address holding the given values.
+func newTestDataAddressValue(values map[string]tftypes.Value) tftypes.Value {
+	dataType := DataAssetsSchema().GetType().TerraformType(context.Background()).(tftypes.Object)
+	return newTestObjectValue(dataType, values)
+}
+
+func TestAssetsResource_ConfigValidators(t *testing.T) {
+	ctx := context.Background()
+	dataType := DataAssetsSchema().GetType().TerraformType(ctx).(tftypes.Object)
+	dataAddress := func(blocks ...string) tftypes.Value {
+		values := map[string]tftypes.Value{}
+		for _, block := range blocks {
+			switch blockType := dataType.AttributeTypes[block].(type) {
+			case tftypes.Object:
+				values[block] = newTestObjectValue(blockType, nil)
+			case tftypes.Map:
+				values[block] = tftypes.NewValue(blockType, map[string]tftypes.Value{"type": tftypes.NewValue(tftypes.String, "Custom")})
+			default:
+				values[block] = tftypes.NewValue(tftypes.String, `{"type": "Custom"}`)
+			}
+		}
+		return newTestObjectValue(dataType, values)
+	}
+
+	tests := []struct {
+		name          string
+		data          tftypes.Value
+		expectedError bool
+	}{
+		{
+			name: "http data address",
+			data: dataAddress("http"),
+		},
+		{
+			name: "custom data address",
+			data: dataAddress("custom"),
+		},
+		{
+			name: "kafka data address",
+			data: dataAddress("kafka"),
+		},
+		{
+			name:          "custom and custom properties",
+			data:          dataAddress("custom", "custom_properties"),
+			expectedError: true,
+		},
+		{
+			name:          "kafka and sql data addresses",
+			data:          dataAddress("kafka", "sql"),
+			expectedError: true,
+		},
+		{
+			name:          "no data address",
+			data:          dataAddress(),
+			expectedError: true,
+		},
+		{
+			name:          "several data addresses",
+			data:          dataAddress("s3", "azure"),
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &AssetsResource{}
+			config := newTestResourceConfig(r, map[string]tftypes.Value{"data": tt.data})
+
+			var diags diag.Diagnostics
+			for _, v := range r.ConfigValidators(ctx) {
+				resp := &fwresource.ValidateConfigResponse{}
+				v.ValidateResource(ctx, fwresource.ValidateConfigRequest{Config: config}, resp)
+				diags.Append(resp.Diagnostics...)
+			}
+
+			assert.Equal(t, tt.expectedError, diags.HasError(), diags)
+		})
+	}
+}
+
+func TestDataAddress_properties(t *testing.T) {
+	tests := []struct {
+		name        string
+		dataAddress DataAddress
+		expected    dataAddressProperties
+		expectedErr bool
+	}{
+		{
+			name: "S3 compatible store",
+			dataAddress: DataAddress{S3StorageDataAddress: &S3StorageDataAddress{
+				BucketName:       types.StringValue("bucket"),
+				Region:           types.StringValue("eu-central-1"),
+				ObjectPrefix:     types.StringValue("exports/"),
+				EndpointOverride: types.StringValue("http://minio:9000"),
+				KeyName:          types.StringValue("minio-credentials"),
+			}},
+			expected: dataAddressProperties{
+				"type":             "AmazonS3",
+				"bucketName":       "bucket",
+				"region":           "eu-central-1",
+				"objectPrefix":     "exports/",
+				"endpointOverride": "http://minio:9000",
+				"keyName":          "minio-credentials",
+			},
+		},
+		{
+			name: "HTTP",
+			dataAddress: DataAddress{HttpDataAddress: &HttpDataAddress{
+				BaseUrl: types.StringValue("https://example.com"),
+				Method:  types.StringNull(),
+			}},
+			expected: dataAddressProperties{"type": "HttpData", "baseUrl": "https://example.com"},
+		},
+		{
+			name: "HTTP backend protected by OAuth2",
+			dataAddress: DataAddress{HttpDataAddress: &HttpDataAddress{
+				BaseUrl:   types.StringValue("https://example.com"),
+				ProxyPath: types.BoolValue(true),
+				ProxyBody: types.BoolValue(false),
+				Headers: types.MapValueMust(types.StringType, map[string]attr.Value{
+					"X-Tenant": types.StringValue("tenant-1"),
+				}),
+				OAuth2: &HttpOAuth2{
+					TokenUrl:            types.StringValue("https://example.com/token"),
+					ClientId:            types.StringValue("client"),
+					ClientSecretKeyName: types.StringValue("client-secret"),
+					PrivateKeyName:      types.StringNull(),
+				},
+			}},
+			expected: dataAddressProperties{
+				"type":                       "HttpData",
+				"baseUrl":                    "https://example.com",
+				"proxyPath":                  "true",
+				"proxyBody":                  "false",
+				"header:X-Tenant":            "tenant-1",
+				"oauth2:tokenUrl":            "https://example.com/token",
+				"oauth2:clientId":            "client",
+				"oauth2:clientSecretKeyName": "client-secret",
+			},
+		},
+		{
+			name: "Kafka",
+			dataAddress: DataAddress{KafkaDataAddress: &KafkaDataAddress{
+				Topic: types.StringValue("orders"),
+				BootstrapServers: types.ListValueMust(types.StringType, []attr.Value{
+					types.StringValue("kafka-1:9092"),
+					types.StringValue("kafka-2:9092"),
+				}),
+				SecurityProtocol: types.StringValue("SASL_SSL"),
+				SaslMechanism:    types.StringValue("PLAIN"),
+				SaslKeyName:      types.StringValue("kafka-credentials"),
+			}},
+			expected: dataAddressProperties{
+				"type":                    "Kafka",
+				"topic":                   "orders",
+				"kafka.bootstrap.servers": "kafka-1:9092,kafka-2:9092",
+				"kafka.security.protocol": "SASL_SSL",
+				"kafka.sasl.mechanism":    "PLAIN",
+				"keyName":                 "kafka-credentials",
+			},
+		},
+		{
+			name: "GCS",
+			dataAddress: DataAddress{GCSDataAddress: &GCSDataAddress{
+				BucketName:            types.StringValue("bucket"),
+				ServiceAccountKeyName: types.StringValue("gcs-service-account"),
+			}},
+			expected: dataAddressProperties{
+				"type":                     "GoogleCloudStorage",
+				"bucket_name":              "bucket",
+				"service_account_key_name": "gcs-service-account",
+			},
+		},
+		{
+			name: "SQL",
+			dataAddress: DataAddress{SQLDataAddress: &SQLDataAddress{
+				DataSourceName: types.StringValue("warehouse"),
+				Query:          types.StringValue("SELECT * FROM orders"),
+			}},
+			expected: dataAddressProperties{"type": "Sql", "dataSourceName": "warehouse", "query": "SELECT * FROM orders"},
+		},
+		{
+			name:        "custom",
+			dataAddress: DataAddress{CustomDataAddress: jsonStringValue{StringValue: types.StringValue(`{"type": "Custom", "size": 2}`)}},
+			expected:    dataAddressProperties{"type": "Custom", "size": 2.0},
+		},
+		{
+			name: "custom properties",
+			dataAddress: DataAddress{CustomProperties: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"type":     types.StringValue("Custom"),
+				"location": types.StringValue("archive"),
+			})},
+			expected: dataAddressProperties{"type": "Custom", "location": "archive"},
+		},
+		{
+			name:        "no data address",
+			dataAddress: DataAddress{CustomDataAddress: jsonStringValue{StringValue: types.StringNull()}, CustomProperties: types.MapNull(types.StringType)},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			properties, err := tt.dataAddress.properties()
+			assert.Equal(t, tt.expectedErr, err != nil, err)
+			assert.Equal(t, tt.expected, properties)
+		})
+	}
+}
+
+func TestAssetsResource_UpgradeState(t *testing.T) {
+	tests := []struct {
+		name          string
+		state         string
+		expected      string
+		expectedError bool
+	}{
+		{
+			name:     "proxy flags",
+			state:    `{"id": "asset-1", "data": {"http": {"base_url": "https://example.com", "proxy_path": "true", "proxy_body": "FALSE", "proxy_method": ""}}}`,
+			expected: `{"id": "asset-1", "data": {"http": {"base_url": "https://example.com", "proxy_path": true, "proxy_body": false, "proxy_method": null, "proxy_query_params": null}}}`,
+		},
+		{
+			name:     "other data address",
+			state:    `{"id": "asset-1", "data": {"s3": {"bucket_name": "bucket"}, "http": null}}`,
+			expected: `{"id": "asset-1", "data": {"s3": {"bucket_name": "bucket"}, "http": null}}`,
+		},
+		{
+			name:          "invalid proxy flag",
+			state:         `{"id": "asset-1", "data": {"http": {"proxy_path": "yes"}}}`,
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upgrader := (&AssetsResource{}).UpgradeState(context.Background())[0]
+			resp := &fwresource.UpgradeStateResponse{}
+			upgrader.StateUpgrader(context.Background(), fwresource.UpgradeStateRequest{
+				RawState: &tfprotov6.RawState{JSON: []byte(tt.state)},
+			}, resp)
+
+			assert.Equal(t, tt.expectedError, resp.Diagnostics.HasError(), resp.Diagnostics)
+			if !tt.expectedError {
+				assert.JSONEq(t, tt.expected, string(resp.DynamicValue.JSON))
+			}
+		})
+	}
+}
+
+func TestAzureStorageDataAddress_refresh(t *testing.T) {
+	prior := AzureStorageDataAddress{
+		Container:  types.StringValue("container"),
+		Account:    types.StringValue("account"),
+		BlobName:   types.StringNull(),
+		BlobPrefix: types.StringValue("exports/"),
+		FolderName: types.StringNull(),
+		KeyName:    types.StringValue("account-key"),
+	}
+
+	tests := []struct {
+		name       string
+		properties map[string]string
+		expected   AzureStorageDataAddress
+	}{
+		{
+			name: "changed container",
+			properties: map[string]string{
+				"type":       "AzureStorage",
+				"container":  "other",
+				"account":    "account",
+				"blobPrefix": "exports/",
+				"keyName":    "account-key",
+			},
+			expected: AzureStorageDataAddress{
+				Container:  types.StringValue("other"),
+				Account:    types.StringValue("account"),
+				BlobName:   types.StringNull(),
+				BlobPrefix: types.StringValue("exports/"),
+				FolderName: types.StringNull(),
+				KeyName:    types.StringValue("account-key"),
+			},
+		},
+		{
+			name:       "redacted key name",
+			properties: map[string]string{"type": "AzureStorage", "container": "container", "account": "account"},
+			expected:   prior,
+		},
+		{
+			name:       "other data address",
+			properties: map[string]string{"type": "HttpData", "container": "other"},
+			expected:   prior,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataAddress := prior
+			dataAddress.refresh(tt.properties)
+			assert.Equal(t, tt.expected, dataAddress)
+		})
+	}
+}
+
+func TestDataAddress_refresh(t *testing.T) {
+	dataAddress := DataAddress{KafkaDataAddress: &KafkaDataAddress{
+		Topic:            types.StringValue("orders"),
+		BootstrapServers: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("kafka-1:9092")}),
+		SecurityProtocol: types.StringNull(),
+		SaslMechanism:    types.StringNull(),
+		SaslKeyName:      types.StringValue("kafka-credentials"),
+	}}
+	assert.True(t, dataAddress.isRefreshed())
+
+	properties, err := dataAddress.properties()
+	assert.NoError(t, err)
+	read := map[string]string{}
+	for key, value := range properties {
+		read[key] = value.(string)
+	}
+	read["topic"] = "payments"
+	read[kafkaBootstrapServersProperty] = "kafka-1:9092, kafka-2:9092"
+	delete(read, "keyName")
+
+	dataAddress.refresh(read)
+	assert.Equal(t, &KafkaDataAddress{
+		Topic: types.StringValue("payments"),
+		BootstrapServers: types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("kafka-1:9092"),
+			types.StringValue("kafka-2:9092"),
+		}),
+		SecurityProtocol: types.StringNull(),
+		SaslMechanism:    types.StringNull(),
+		SaslKeyName:      types.StringValue("kafka-credentials"),
+	}, dataAddress.KafkaDataAddress)
+
+	assert.False(t, DataAddress{HttpDataAddress: &HttpDataAddress{}}.isRefreshed())
+}
+
+func TestAssetsResource_PlanResourceChange_replace(t *testing.T) {
+	customProperties := func(value string) tftypes.Value {
+		return newTestDataAddressValue(map[string]tftypes.Value{
+			"custom_properties": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+				"type":   tftypes.NewValue(tftypes.String, "Custom"),
+				"bucket": tftypes.NewValue(tftypes.String, value),
+			}),
+		})
+	}
+	stringMap := func(key, value string) tftypes.Value {
+		return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			key: tftypes.NewValue(tftypes.String, value),
+		})
+	}
+	prior := map[string]tftypes.Value{
+		"id":                 tftypes.NewValue(tftypes.String, "asset-1"),
+		"asset_id":           tftypes.NewValue(tftypes.String, "asset-1"),
+		"asset":              stringMap("name", "Asset"),
+		"private_properties": stringMap("billing", "internal"),
+		"data":               customProperties("bucket-1"),
+	}
+	with := func(name string, value tftypes.Value) map[string]tftypes.Value {
+		values := map[string]tftypes.Value{name: value}
+		for k, v := range prior {
+			if k != name {
+				values[k] = v
+			}
+		}
+		return values
+	}
+
+	tests := []struct {
+		name            string
+		proposed        map[string]tftypes.Value
+		expectedReplace []string
+	}{
+		{
+			name:     "unchanged",
+			proposed: prior,
+		},
+		{
+			name:            "asset",
+			proposed:        with("asset", stringMap("name", "Renamed")),
+			expectedReplace: []string{"asset"},
+		},
+		{
+			name:            "properties json",
+			proposed:        with("properties_json", tftypes.NewValue(tftypes.String, `{"version": 2}`)),
+			expectedReplace: []string{"properties_json"},
+		},
+		{
+			name:            "private properties",
+			proposed:        with("private_properties", stringMap("billing", "external")),
+			expectedReplace: []string{"private_properties"},
+		},
+		{
+			name:            "data address",
+			proposed:        with("data", customProperties("bucket-2")),
+			expectedReplace: []string{"data"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := planTestResource(t, "edc_asset", NewAssetsResource(), prior, tt.proposed, "id", "created_at")
+			assert.Empty(t, resp.Diagnostics)
+
+			var expectedReplace []*tftypes.AttributePath
+			for _, name := range tt.expectedReplace {
+				expectedReplace = append(expectedReplace, tftypes.NewAttributePath().WithAttributeName(name))
+			}
+			assert.ElementsMatch(t, expectedReplace, resp.RequiresReplace)
+		})
+	}
+}
+
+func TestAssetsResource_ValidateResourceConfig_httpAuth(t *testing.T) {
+	ctx := context.Background()
+	dataType := DataAssetsSchema().GetType().TerraformType(ctx).(tftypes.Object)
+	httpType := dataType.AttributeTypes["http"].(tftypes.Object)
+	oauth2Type := httpType.AttributeTypes["oauth2"].(tftypes.Object)
+
+	tests := []struct {
+		name          string
+		http          map[string]tftypes.Value
+		expectedError bool
+	}{
+		{
+			name: "base url only",
+			http: map[string]tftypes.Value{},
+		},
+		{
+			name: "auth key and secret name",
+			http: map[string]tftypes.Value{
+				"auth_key":    tftypes.NewValue(tftypes.String, "Authorization"),
+				"secret_name": tftypes.NewValue(tftypes.String, "backend-token"),
+			},
+		},
+		{
+			name: "auth key and auth code",
+			http: map[string]tftypes.Value{
+				"auth_key":  tftypes.NewValue(tftypes.String, "Authorization"),
+				"auth_code": tftypes.NewValue(tftypes.String, "secret"),
+			},
+		},
+		{
+			name: "oauth2",
+			http: map[string]tftypes.Value{
+				"oauth2": newTestObjectValue(oauth2Type, map[string]tftypes.Value{
+					"token_url":              tftypes.NewValue(tftypes.String, "https://example.com/token"),
+					"client_id":              tftypes.NewValue(tftypes.String, "client"),
+					"client_secret_key_name": tftypes.NewValue(tftypes.String, "client-secret"),
+				}),
+			},
+		},
+		{
+			name: "auth key without credential",
+			http: map[string]tftypes.Value{
+				"auth_key": tftypes.NewValue(tftypes.String, "Authorization"),
+			},
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.http["base_url"] = tftypes.NewValue(tftypes.String, "https://example.com")
+			config := newTestResourceConfig(NewAssetsResource(), map[string]tftypes.Value{
+				"asset": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+					"name": tftypes.NewValue(tftypes.String, "Asset"),
+				}),
+				"data": newTestObjectValue(dataType, map[string]tftypes.Value{
+					"http": newTestObjectValue(httpType, tt.http),
+				}),
+			}).Raw
+			dynamicValue, err := tfprotov6.NewDynamicValue(config.Type(), config)
+			assert.NoError(t, err)
+
+			server, err := providerserver.NewProtocol6WithError(New("test")())()
+			assert.NoError(t, err)
+			_, err = server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+			assert.NoError(t, err)
+
+			resp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+				TypeName: "edc_asset",
+				Config:   &dynamicValue,
+			})
+			assert.NoError(t, err)
+
+			if tt.expectedError {
+				assert.Len(t, resp.Diagnostics, 1)
+			} else {
+				assert.Empty(t, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const defaultConnectorHost = "http://localhost"
+
+// connectorConfig holds the values derived from an EDC connector
+// configuration.properties file. Empty fields were not found in the file.
+type connectorConfig struct {
+	Token              string
+	DefaultEndpoint    string
+	ControlEndpoint    string
+	ManagementEndpoint string
+	ProtocolEndpoint   string
+	PublicEndpoint     string
+}
+
+// connectorTokenProperties lists the properties holding the management API
+// key, by order of precedence.
+var connectorTokenProperties = []string{
+	"edc.api.auth.key",
+	"edc.api.control.auth.apikey.value",
+}
+
+// loadConnectorConfigFile parses the connector configuration file located at
+// filePath and derives the connector endpoints from the web.http.* contexts,
+// served on the given host with their ports shifted by portOffset.
+func loadConnectorConfigFile(filePath, host string, portOffset int64) (*connectorConfig, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	properties, err := parseProperties(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", filePath, err)
+	}
+
+	cfg, err := newConnectorConfig(properties, host, portOffset)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", filePath, err)
+	}
+	return cfg, nil
+}
+
+func newConnectorConfig(properties map[string]string, host string, portOffset int64) (*connectorConfig, error) {
+	if host == "" {
+		host = defaultConnectorHost
+	}
+	if !strings.Contains(host, "://") {
+		host = "http://" + host
+	}
+	host = strings.TrimSuffix(host, "/")
+
+	cfg := &connectorConfig{}
+	for name, endpoint := range map[string]*string{
+		"":           &cfg.DefaultEndpoint,
+		"control":    &cfg.ControlEndpoint,
+		"management": &cfg.ManagementEndpoint,
+		"protocol":   &cfg.ProtocolEndpoint,
+		"public":     &cfg.PublicEndpoint,
+	} {
+		address, err := webContextAddress(properties, host, name, portOffset)
+		if err != nil {
+			return nil, err
+		}
+		*endpoint = address
+	}
+
+	for _, key := range connectorTokenProperties {
+		if token := properties[key]; token != "" {
+			cfg.Token = token
+			break
+		}
+	}
+
+	return cfg, nil
+}
+
+// webContextAddress builds the address of a web.http context out of its port,
+// shifted by portOffset, and path properties. The default context is selected
+// with an empty name.
+func webContextAddress(properties map[string]string, host, name string, portOffset int64) (string, error) {
+	prefix := "web.http."
+	if name != "" {
+		prefix += name + "."
+	}
+
+	port := properties[prefix+"port"]
+	if port == "" {
+		return "", nil
+	}
+
+	if portOffset != 0 {
+		number, err := strconv.ParseInt(port, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("%sport is not a number: %q", prefix, port)
+		}
+		if number+portOffset < 1 || number+portOffset > 65535 {
+			return "", fmt.Errorf("%sport shifted by %d is not a valid port: %d", prefix, portOffset, number+portOffset)
+		}
+		port = strconv.FormatInt(number+portOffset, 10)
+	}
+
+	contextPath := properties[prefix+"path"]
+	if contextPath != "" && !strings.HasPrefix(contextPath, "/") {
+		contextPath = "/" + contextPath
+	}
+
+	return fmt.Sprintf("%s:%s%s", host, port, contextPath), nil
+}
+
+// parseProperties reads a Java properties document. It supports comments,
+// the "=", ":" and whitespace separators, line continuations and the usual
+// escape sequences.
+func parseProperties(r io.Reader) (map[string]string, error) {
+	properties := map[string]string{}
+	scanner := bufio.NewScanner(r)
+
+	var logicalLine strings.Builder
+	for scanner.Scan() {
+		line := strings.TrimLeft(scanner.Text(), " \t\f")
+
+		if logicalLine.Len() == 0 && (line == "" || line[0] == '#' || line[0] == '!') {
+			continue
+		}
+
+		if continuesOnNextLine(line) {
+			logicalLine.WriteString(line[:len(line)-1])
+			continue
+		}
+
+		logicalLine.WriteString(line)
+		key, value := splitProperty(logicalLine.String())
+		logicalLine.Reset()
+
+		properties[unescapeProperty(key)] = unescapeProperty(value)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if logicalLine.Len() != 0 {
+		key, value := splitProperty(logicalLine.String())
+		properties[unescapeProperty(key)] = unescapeProperty(value)
+	}
+
+	return properties, nil
+}
+
+// continuesOnNextLine reports whether the line ends with an odd number of
+// backslashes.
+func continuesOnNextLine(line string) bool {
+	backslashes := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		backslashes++
+	}
+	return backslashes%2 == 1
+}
+
+func splitProperty(line string) (string, string) {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '=', ':':
+			return line[:i], strings.TrimLeft(line[i+1:], " \t\f")
+		case ' ', '\t', '\f':
+			value := strings.TrimLeft(line[i:], " \t\f")
+			if value != "" && (value[0] == '=' || value[0] == ':') {
+				value = strings.TrimLeft(value[1:], " \t\f")
+			}
+			return line[:i], value
+		}
+	}
+	return line, ""
+}
+
+func unescapeProperty(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		switch s[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		case 'u':
+			var r rune
+			if i+4 < len(s) {
+				if _, err := fmt.Sscanf(s[i+1:i+5], "%04x", &r); err == nil {
+					b.WriteRune(r)
+					i += 4
+					continue
+				}
+			}
+			b.WriteByte('u')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseProperties(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]string
+	}{
+		{
+			name: "separators and comments",
+			input: `
+# comment
+! another comment
+web.http.port=9191
+web.http.path : /api
+edc.api.auth.key secret
+  indented.key =  value with spaces
+empty.key=
+`,
+			expected: map[string]string{
+				"web.http.port":    "9191",
+				"web.http.path":    "/api",
+				"edc.api.auth.key": "secret",
+				"indented.key":     "value with spaces",
+				"empty.key":        "",
+			},
+		},
+		{
+			name: "line continuations",
+			input: `edc.participant.id=urn:connector:\
+    provider
+trailing.backslash=C:\\`,
+			expected: map[string]string{
+				"edc.participant.id": "urn:connector:provider",
+				"trailing.backslash": `C:\`,
+			},
+		},
+		{
+			name:  "escape sequences",
+			input: `escaped\=key=tab\there \u0041`,
+			expected: map[string]string{
+				"escaped=key": "tab\there A",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			properties, err := parseProperties(strings.NewReader(tt.input))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, properties)
+		})
+	}
+}
+
+func Test_newConnectorConfig(t *testing.T) {
+	properties := map[string]string{
+		"web.http.port":                     "9191",
+		"web.http.path":                     "/api",
+		"web.http.management.port":          "9193",
+		"web.http.management.path":          "api/v1/data",
+		"web.http.control.port":             "9192",
+		"edc.api.control.auth.apikey.value": "control-key",
+		"edc.api.auth.key":                  "management-key",
+	}
+	tests := []struct {
+		name          string
+		host          string
+		portOffset    int64
+		expected      connectorConfig
+		expectedError bool
+	}{
+		{
+			name: "default host",
+			host: "",
+			expected: connectorConfig{
+				Token:              "management-key",
+				DefaultEndpoint:    "http://localhost:9191/api",
+				ManagementEndpoint: "http://localhost:9193/api/v1/data",
+				ControlEndpoint:    "http://localhost:9192",
+			},
+		},
+		{
+			name: "host without scheme",
+			host: "connector.internal",
+			expected: connectorConfig{
+				Token:              "management-key",
+				DefaultEndpoint:    "http://connector.internal:9191/api",
+				ManagementEndpoint: "http://connector.internal:9193/api/v1/data",
+				ControlEndpoint:    "http://connector.internal:9192",
+			},
+		},
+		{
+			name: "host with scheme",
+			host: "https://connector.internal/",
+			expected: connectorConfig{
+				Token:              "management-key",
+				DefaultEndpoint:    "https://connector.internal:9191/api",
+				ManagementEndpoint: "https://connector.internal:9193/api/v1/data",
+				ControlEndpoint:    "https://connector.internal:9192",
+			},
+		},
+		{
+			name:       "port offset",
+			portOffset: 20000,
+			expected: connectorConfig{
+				Token:              "management-key",
+				DefaultEndpoint:    "http://localhost:29191/api",
+				ManagementEndpoint: "http://localhost:29193/api/v1/data",
+				ControlEndpoint:    "http://localhost:29192",
+			},
+		},
+		{
+			name:          "port offset out of range",
+			portOffset:    60000,
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := newConnectorConfig(properties, tt.host, tt.portOffset)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, *cfg)
+		})
+	}
+}
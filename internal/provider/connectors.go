@@ -0,0 +1,325 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Think-iT-Labs/edc-connector-client-go/config"
+	"github.com/Think-iT-Labs/edc-connector-client-go/edc"
+	"github.com/Think-iT-Labs/edc-connector-client-go/service/assets"
+	"github.com/Think-iT-Labs/edc-connector-client-go/service/contractdefinition"
+	"github.com/Think-iT-Labs/edc-connector-client-go/service/policies"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// EDCConnectors is the data shared by the provider with its resources and
+// data sources. It holds one configuration and client set per connector.
+type EDCConnectors struct {
+	// defaultConnector is used when no connector is selected. It is nil
+	// when only named connectors are configured.
+	defaultConnector *managedConnector
+	named            map[string]*managedConnector
+}
+
+// managedConnector rebuilds the client set of a connector whenever its token
+// is refreshed or the version of its management API is detected. Client sets
+// are never modified once built, so that requests running concurrently keep
+// using the token they started with.
+type managedConnector struct {
+	mu        sync.Mutex
+	token     *tokenSource
+	addresses edc.Addresses
+	prefixes  jsonldPrefixes
+	current   *EDCConnector
+	// planned holds the identifiers of the policies and assets planned by
+	// the resources, by kind.
+	planned map[string]map[string]bool
+}
+
+// newManagedConnector creates the client set of a connector. When apiVersion
+// is "auto", the management API clients are only created once the version is
+// detected.
+func newManagedConnector(token *tokenSource, addresses edc.Addresses, apiVersion string) (*managedConnector, error) {
+	if apiVersion == managementAPIAuto {
+		apiVersion = ""
+	}
+
+	connector, err := newEDCConnector(token.token, addresses, apiVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &managedConnector{
+		token:     token,
+		addresses: addresses,
+		current:   connector,
+	}, nil
+}
+
+// get returns the client set of the connector, refreshing its token first
+// when it expired and detecting the version of its management API when it is
+// not known yet.
+func (m *managedConnector) get(ctx context.Context) (*EDCConnector, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	apiVersion := m.current.ManagementAPIVersion
+	rebuild := false
+
+	if m.token != nil && m.token.Expired() {
+		tflog.Debug(ctx, "refreshing the connector token")
+		if err := m.token.refresh(ctx); err != nil {
+			return nil, err
+		}
+		rebuild = true
+	}
+
+	if apiVersion == "" {
+		version, reason := detectManagementAPIVersion(ctx, m.current)
+		if version == "" {
+			return nil, fmt.Errorf("unable to detect the version of the management API: %s", reason)
+		}
+		tflog.Info(ctx, "detected the version of the management API", map[string]interface{}{
+			"management_api_version": version,
+		})
+		apiVersion = version
+		rebuild = true
+	}
+
+	if rebuild {
+		if err := m.rebuild(apiVersion); err != nil {
+			return nil, err
+		}
+	}
+
+	return m.current, nil
+}
+
+// useDetectedAPIVersion sets the version of the management API detected by
+// the health check, when it is detected automatically and not known yet.
+func (m *managedConnector) useDetectedAPIVersion(apiVersion string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if apiVersion == "" || m.current.ManagementAPIVersion != "" {
+		return nil
+	}
+	return m.rebuild(apiVersion)
+}
+
+func (m *managedConnector) rebuild(apiVersion string) error {
+	token := m.current.Config.AuthToken
+	if m.token != nil {
+		token = m.token.token
+	}
+
+	connector, err := newEDCConnector(token, m.addresses, apiVersion)
+	if err != nil {
+		return err
+	}
+	connector.Prefixes = m.prefixes
+	connector.assetCache = m.current.assetCache
+	m.current = connector
+	return nil
+}
+
+// setPrefixes sets the JSON-LD prefixes configured for the connector.
+func (m *managedConnector) setPrefixes(prefixes jsonldPrefixes) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.prefixes = prefixes
+	m.current.Prefixes = prefixes
+}
+
+// EDCConnector holds the configuration and the API clients of a connector.
+type EDCConnector struct {
+	Config *edc.Config
+	// ManagementAPIVersion is empty, and the API clients nil, until the
+	// version is detected.
+	ManagementAPIVersion string
+	Assets               assetsAPI
+	Policies             policiesAPI
+	ContractDefinitions  contractDefinitionsAPI
+	ContractAgreements   contractAgreementsAPI
+	// Prefixes are the JSON-LD prefixes configured for the connector.
+	Prefixes jsonldPrefixes
+	// assetCache is shared by the client sets successively built for the
+	// connector.
+	assetCache *assetCache
+}
+
+func newEDCConnector(token string, addresses edc.Addresses, apiVersion string) (*EDCConnector, error) {
+	cfg, err := config.LoadConfig(token, addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	connector := &EDCConnector{
+		Config:               cfg,
+		ManagementAPIVersion: apiVersion,
+		assetCache:           &assetCache{},
+	}
+
+	switch apiVersion {
+	case "":
+	case managementAPIV1:
+		// Every service client overrides the error factory of its HTTP
+		// client, so each of them gets its own copy to keep error messages
+		// accurate.
+		assetsClient, err := assets.New(withOwnHTTPClient(cfg))
+		if err != nil {
+			return nil, err
+		}
+		connector.Assets = &legacyAssets{sdk: assetsClient, client: newLegacyClient(cfg)}
+		policiesClient, err := policies.New(withOwnHTTPClient(cfg))
+		if err != nil {
+			return nil, err
+		}
+		connector.Policies = &legacyPolicies{sdk: policiesClient, client: newLegacyClient(cfg)}
+		contractDefinitionsClient, err := contractdefinition.New(withOwnHTTPClient(cfg))
+		if err != nil {
+			return nil, err
+		}
+		connector.ContractDefinitions = &legacyContractDefinitions{sdk: contractDefinitionsClient, client: newLegacyClient(cfg)}
+		connector.ContractAgreements = &legacyContractAgreements{client: newLegacyClient(cfg)}
+	case managementAPIV2, managementAPIV3:
+		client := newJSONLDClient(cfg, apiVersion)
+		connector.Assets = &jsonldAssets{client: client}
+		connector.Policies = &jsonldPolicies{client: client}
+		connector.ContractDefinitions = &jsonldContractDefinitions{client: client}
+		connector.ContractAgreements = &jsonldContractAgreements{client: client}
+	default:
+		return nil, fmt.Errorf("unsupported management API version %q", apiVersion)
+	}
+
+	return connector, nil
+}
+
+func withOwnHTTPClient(cfg *edc.Config) edc.Config {
+	httpClient := *cfg.HTTPClient
+	serviceCfg := cfg.Copy()
+	serviceCfg.HTTPClient = &httpClient
+	return serviceCfg
+}
+
+// Connector returns the connector selected by the given name, or the default
+// connector when the name is null or empty.
+func (c *EDCConnectors) Connector(ctx context.Context, name types.String) (*EDCConnector, diag.Diagnostics) {
+	managed, diags := c.managed(name)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	connector, err := managed.get(ctx)
+	if err != nil {
+		diags.AddError("Unable to Prepare EDC API Client", connectorErrorDetail+err.Error())
+	}
+	return connector, diags
+}
+
+// connectorErrorDetail introduces the errors of managedConnector.get.
+const connectorErrorDetail = "The provider could not refresh the expired token of the connector, or detect the version of its management API.\n\nError: "
+
+// managed returns the connector selected by the given name, as Connector.
+func (c *EDCConnectors) managed(name types.String) (*managedConnector, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	managed := c.defaultConnector
+	if name.IsNull() || name.IsUnknown() || name.ValueString() == "" {
+		if managed == nil {
+			diags.AddAttributeError(
+				path.Root("connector"),
+				"Missing EDC Connector",
+				"The provider does not configure a default connector. "+
+					"Set the connector attribute to one of the connectors configured in the provider: "+c.names(),
+			)
+			return nil, diags
+		}
+	} else {
+		var ok bool
+		managed, ok = c.named[name.ValueString()]
+		if !ok {
+			diags.AddAttributeError(
+				path.Root("connector"),
+				"Unknown EDC Connector",
+				fmt.Sprintf("The connector %q is not configured in the provider. Available connectors: %s", name.ValueString(), c.names()),
+			)
+			return nil, diags
+		}
+	}
+	return managed, diags
+}
+
+func (c *EDCConnectors) names() string {
+	names := make([]string, 0, len(c.named))
+	for name := range c.named {
+		names = append(names, fmt.Sprintf("%q", name))
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// ImportStatePassthroughID imports a resource from its identifier, optionally
+// prefixed with the name of the connector managing it: "<connector>/<id>".
+func (c *EDCConnectors) ImportStatePassthroughID(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if c != nil {
+		if name, id, found := strings.Cut(req.ID, "/"); found {
+			if _, ok := c.named[name]; ok {
+				resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("connector"), name)...)
+				resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+				return
+			}
+		}
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// connectorResourceAttribute returns the schema of the attribute selecting the
+// connector managing a resource.
+func connectorResourceAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Name of the provider `connectors` entry managing this resource. Defaults to the connector configured at the provider level.",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// createdAtAttribute returns the schema of the creation time of a resource,
+// as reported by its connector.
+func createdAtAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Computed:            true,
+		MarkdownDescription: "Creation time, in milliseconds since the epoch, reported by the connector.",
+		PlanModifiers: []planmodifier.Int64{
+			int64planmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+// connectorDataSourceAttribute returns the schema of the attribute selecting
+// the connector read by a data source.
+func connectorDataSourceAttribute() datasourceschema.StringAttribute {
+	return datasourceschema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Name of the provider `connectors` entry to read from. Defaults to the connector configured at the provider level.",
+	}
+}
@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Think-iT-Labs/edc-connector-client-go/edc"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEDCConnectors_Connector(t *testing.T) {
+	defaultConnector := &EDCConnector{ManagementAPIVersion: managementAPIV1}
+	consumerConnector := &EDCConnector{ManagementAPIVersion: managementAPIV1}
+	managedDefault := &managedConnector{current: defaultConnector}
+	managedConsumer := &managedConnector{current: consumerConnector}
+
+	tests := []struct {
+		name          string
+		connectors    EDCConnectors
+		connector     types.String
+		expected      *EDCConnector
+		expectedError bool
+	}{
+		{
+			name:       "default connector",
+			connectors: EDCConnectors{defaultConnector: managedDefault},
+			connector:  types.StringNull(),
+			expected:   defaultConnector,
+		},
+		{
+			name: "named connector",
+			connectors: EDCConnectors{
+				defaultConnector: managedDefault,
+				named:            map[string]*managedConnector{"consumer": managedConsumer},
+			},
+			connector: types.StringValue("consumer"),
+			expected:  consumerConnector,
+		},
+		{
+			name: "unknown named connector",
+			connectors: EDCConnectors{
+				defaultConnector: managedDefault,
+				named:            map[string]*managedConnector{"consumer": managedConsumer},
+			},
+			connector:     types.StringValue("provider"),
+			expectedError: true,
+		},
+		{
+			name: "missing default connector",
+			connectors: EDCConnectors{
+				named: map[string]*managedConnector{"consumer": managedConsumer},
+			},
+			connector:     types.StringNull(),
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connector, diags := tt.connectors.Connector(context.Background(), tt.connector)
+
+			assert.Equal(t, tt.expectedError, diags.HasError())
+			if !tt.expectedError {
+				assert.Same(t, tt.expected, connector)
+			} else {
+				assert.ElementsMatch(t, []string{"connector"}, getErrorPaths(diags.Errors()))
+			}
+		})
+	}
+}
+
+func Test_newEDCConnector(t *testing.T) {
+	address := "http://localhost:29193/api"
+	addresses := edc.Addresses{
+		Default:    &address,
+		Management: &address,
+		Protocol:   &address,
+		Public:     &address,
+		Control:    &address,
+	}
+
+	connector, err := newEDCConnector("1234", addresses, managementAPIV1)
+	assert.NoError(t, err)
+	legacyAssetsClient, _ := connector.Assets.(*legacyAssets)
+	assetsClient := legacyAssetsClient.sdk
+	legacyPoliciesClient, _ := connector.Policies.(*legacyPolicies)
+	policiesClient := legacyPoliciesClient.sdk
+	legacyContractDefinitionsClient, _ := connector.ContractDefinitions.(*legacyContractDefinitions)
+	contractDefinitionsClient := legacyContractDefinitionsClient.sdk
+	assert.NotSame(t, assetsClient.HTTPClient, policiesClient.HTTPClient)
+	assert.NotSame(t, policiesClient.HTTPClient, contractDefinitionsClient.HTTPClient)
+	assert.Equal(t, address, *assetsClient.Addresses.Management)
+	assert.Equal(t, address, legacyAssetsClient.client.baseURL)
+
+	connector, err = newEDCConnector("1234", addresses, managementAPIV3)
+	assert.NoError(t, err)
+	jsonldAssetsClient, _ := connector.Assets.(*jsonldAssets)
+	assert.Equal(t, address+"/v3", jsonldAssetsClient.client.baseURL)
+
+	connector, err = newEDCConnector("1234", addresses, "")
+	assert.NoError(t, err)
+	assert.Nil(t, connector.Assets)
+	assert.NotNil(t, connector.Config)
+
+	_, err = newEDCConnector("1234", addresses, "v9")
+	assert.Error(t, err)
+}
+
+func Test_managedConnector_detectAPIVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/management/v3/assets/request" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	address := server.URL + "/management"
+	connector, err := newManagedConnector(newStaticTokenSource("1234"), edc.Addresses{
+		Default:    &address,
+		Management: &address,
+		Protocol:   &address,
+		Public:     &address,
+		Control:    &address,
+	}, managementAPIAuto)
+	assert.NoError(t, err)
+	assert.Nil(t, connector.current.Assets)
+
+	current, err := connector.get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, managementAPIV3, current.ManagementAPIVersion)
+	assert.IsType(t, &jsonldAssets{}, current.Assets)
+}
+
+func Test_managedConnector_get(t *testing.T) {
+	address := "http://localhost:29193/api"
+	addresses := edc.Addresses{
+		Default:    &address,
+		Management: &address,
+		Protocol:   &address,
+		Public:     &address,
+		Control:    &address,
+	}
+
+	source, err := newCommandTokenSource(context.Background(), []string{"echo", `{"token": "first", "expires_in": 3600}`})
+	assert.NoError(t, err)
+
+	connector, err := newManagedConnector(source, addresses, managementAPIV1)
+	assert.NoError(t, err)
+
+	first, err := connector.get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "first", first.Config.AuthToken)
+
+	again, err := connector.get(context.Background())
+	assert.NoError(t, err)
+	assert.Same(t, first, again)
+
+	source.command = []string{"echo", "second"}
+	source.now = func() time.Time { return time.Now().Add(time.Hour) }
+
+	refreshed, err := connector.get(context.Background())
+	assert.NoError(t, err)
+	assert.NotSame(t, first, refreshed)
+	assert.Equal(t, "second", refreshed.Config.AuthToken)
+	assert.Equal(t, "first", first.Config.AuthToken)
+}
+
+func Test_validateConnectorOptions(t *testing.T) {
+	oldEnv := stashEnv()
+	defer popEnv(oldEnv)
+
+	os.Setenv("EDC_TOKEN", "from-env")
+
+	var diags diag.Diagnostics
+	token, _ := validateConnectorOptions(
+		ConnectorModel{
+			ConnectorConfigFile: types.StringValue("../../conf/consumer-connector.config/configuration.properties"),
+		},
+		path.Root("connectors").AtMapKey("consumer"),
+		false,
+		&diags,
+	)
+
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "123456", token)
+
+	diags = nil
+	token, _ = validateConnectorOptions(
+		ConnectorModel{},
+		path.Root("connectors").AtMapKey("consumer"),
+		false,
+		&diags,
+	)
+
+	assert.Equal(t, "", token)
+	assert.Contains(t, getErrorPaths(diags.Errors()), `connectors["consumer"].token`)
+}
+
+func Test_validateConnectorOptions_tokenSources(t *testing.T) {
+	oldEnv := stashEnv()
+	defer popEnv(oldEnv)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("from-file\n"), 0o600))
+	os.Setenv("EDC_CONSUMER_TOKEN", "from-env")
+
+	address := types.StringValue("http://localhost:29193/api")
+	addresses := &Addresses{
+		DefaultEndpoint:    address,
+		ManagementEndpoint: address,
+		ProtocolEndpoint:   address,
+		PublicEndpoint:     address,
+		ControlEndpoint:    address,
+	}
+
+	tests := []struct {
+		name          string
+		data          ConnectorModel
+		expectedToken string
+		expectedPaths []string
+	}{
+		{
+			name:          "token file",
+			data:          ConnectorModel{Addresses: addresses, TokenFile: types.StringValue(tokenFile)},
+			expectedToken: "from-file",
+		},
+		{
+			name:          "missing token file",
+			data:          ConnectorModel{Addresses: addresses, TokenFile: types.StringValue(tokenFile + ".missing")},
+			expectedPaths: []string{`connectors["consumer"].token_file`, `connectors["consumer"].token`},
+		},
+		{
+			name:          "token environment variable",
+			data:          ConnectorModel{Addresses: addresses, TokenEnv: types.StringValue("EDC_CONSUMER_TOKEN")},
+			expectedToken: "from-env",
+		},
+		{
+			name: "token command",
+			data: ConnectorModel{
+				Addresses:    addresses,
+				TokenCommand: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("echo")}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			token, _ := validateConnectorOptions(tt.data, path.Root("connectors").AtMapKey("consumer"), false, &diags)
+
+			assert.Equal(t, tt.expectedToken, token)
+			assert.ElementsMatch(t, tt.expectedPaths, getErrorPaths(diags.Errors()))
+		})
+	}
+}
@@ -4,8 +4,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/Think-iT-Labs/edc-connector-client-go/edc"
-	"github.com/Think-iT-Labs/edc-connector-client-go/service/contractdefinition"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -21,7 +20,7 @@ func NewContractDefinitionDataSource() datasource.DataSource {
 
 // ContractDefinitionDataSource defines the data source implementation.
 type ContractDefinitionDataSource struct {
-	client *contractdefinition.Client
+	connectors *EDCConnectors
 }
 
 // ContractDefinitionDataSourceModel describes the data source data model.
@@ -31,7 +30,9 @@ type ContractDefinitionDataSourceModel struct {
 	ContractPolicyId types.String `tfsdk:"contract_policy_id"`
 	Validity         types.Int64  `tfsdk:"validity"`
 	Criteria         []Criterion  `tfsdk:"criteria"`
+	SelectedAssetIds types.Set    `tfsdk:"selected_asset_ids"`
 	CreatedAt        types.Int64  `tfsdk:"created_at"`
+	Connector        types.String `tfsdk:"connector"`
 }
 
 func (d *ContractDefinitionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -57,20 +58,27 @@ func (d *ContractDefinitionDataSource) Schema(ctx context.Context, req datasourc
 				Computed:            true,
 			},
 			"validity": schema.Int64Attribute{
-				MarkdownDescription: "Validity",
+				MarkdownDescription: "Validity in seconds, null with the JSON-LD management APIs which have no validity",
 				Computed:            true,
 			},
+			"selected_asset_ids": schema.SetAttribute{
+				MarkdownDescription: selectedAssetIdsDescription + " Null, with a warning, when the assets cannot be listed or the criteria evaluated.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 			"created_at": schema.Int64Attribute{
 				MarkdownDescription: "Created at timestamp in seconds",
 				Computed:            true,
 			},
+			"connector": connectorDataSourceAttribute(),
 			"criteria": &schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"operand_left":  schema.StringAttribute{Computed: true},
-						"operator":      schema.StringAttribute{Computed: true},
-						"operand_right": schema.StringAttribute{Computed: true},
+						"operand_left":       schema.StringAttribute{Computed: true},
+						"operator":           schema.StringAttribute{Computed: true},
+						"operand_right":      schema.StringAttribute{Computed: true},
+						"operand_right_list": schema.ListAttribute{Computed: true, ElementType: types.StringType},
 					},
 				},
 			},
@@ -84,26 +92,17 @@ func (d *ContractDefinitionDataSource) Configure(ctx context.Context, req dataso
 		return
 	}
 
-	cfg, ok := req.ProviderData.(*edc.Config)
+	connectors, ok := req.ProviderData.(*EDCConnectors)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *edc.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *EDCConnectors, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	client, err := contractdefinition.New(*cfg)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to initiate contract definition client",
-			fmt.Sprintf("Client Error: %v", err),
-		)
-		return
-	}
-
-	d.client = client
+	d.connectors = connectors
 }
 
 func (d *ContractDefinitionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -116,32 +115,72 @@ func (d *ContractDefinitionDataSource) Read(ctx context.Context, req datasource.
 		return
 	}
 
-	cd, err := d.client.GetContractDefinition(data.Id.ValueString())
+	connector, diags := d.connectors.Connector(ctx, data.Connector)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cd, err := connector.ContractDefinitions.GetContractDefinition(data.Id.ValueString())
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read contract definition, got error: %s", err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.Config.Schema, contractDefinitionAPIPathAliases, "read contract definition", err)
 		return
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("read contract definition %s", cd.Id))
 	data.AccessPolicyId = types.StringValue(cd.AccessPolicyId)
 	data.ContractPolicyId = types.StringValue(cd.ContractPolicyId)
-	data.Validity = types.Int64Value(cd.Validity)
+	data.Validity = types.Int64Null()
+	if cd.Validity != 0 {
+		data.Validity = types.Int64Value(cd.Validity)
+	}
 	data.Criteria = criteriaModel(cd.Criteria)
+	data.SelectedAssetIds = connector.selectedAssets(cd.Criteria, types.SetNull(types.StringType), &resp.Diagnostics)
 	data.CreatedAt = types.Int64Value(cd.CreatedAt)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func criteriaModel(c []contractdefinition.Criterion) []Criterion {
+func criteriaModel(c []criterion) []Criterion {
 	criteria := make([]Criterion, len(c))
 	for i, criterion := range c {
 		criteria[i] = Criterion{
-			OperandLeft:  types.StringValue(criterion.OperandLeft),
-			Operator:     types.StringValue(criterion.Operator),
-			OperandRight: types.StringValue(criterion.OperandRight),
+			OperandLeft:      types.StringValue(criterion.OperandLeft),
+			Operator:         types.StringValue(criterion.Operator),
+			OperandRight:     types.StringNull(),
+			OperandRightList: types.ListNull(types.StringType),
+		}
+
+		switch operandRight := criterion.OperandRight.(type) {
+		case []string:
+			criteria[i].OperandRightList = stringListValue(operandRight)
+		case string:
+			// The JSON-LD APIs compact the lists holding a single value.
+			if criterion.Operator == "in" {
+				criteria[i].OperandRightList = stringListValue([]string{operandRight})
+			} else {
+				criteria[i].OperandRight = types.StringValue(operandRight)
+			}
 		}
 	}
 	return criteria
 }
+
+func stringListValue(values []string) types.List {
+	elements := make([]attr.Value, len(values))
+	for i, value := range values {
+		elements[i] = types.StringValue(value)
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+func stringSetValue(values []string) types.Set {
+	elements := make([]attr.Value, len(values))
+	for i, value := range values {
+		elements[i] = types.StringValue(value)
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
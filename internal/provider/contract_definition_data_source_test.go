@@ -33,7 +33,7 @@ resource "edc_contract_definition" "test" {
 	validity = 600
 	criteria = [
 		{
-			operator = "eq"
+			operator = "="
 			operand_left = "test"
 			operand_right = "test"
 		}
@@ -3,23 +3,33 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
-	"github.com/Think-iT-Labs/edc-connector-client-go/edc"
-	"github.com/Think-iT-Labs/edc-connector-client-go/service/contractdefinition"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ContractDefinitionResource{}
 var _ resource.ResourceWithImportState = &ContractDefinitionResource{}
+var _ resource.ResourceWithModifyPlan = &ContractDefinitionResource{}
 
 func NewContractDefinitionResource() resource.Resource {
 	return &ContractDefinitionResource{}
@@ -27,24 +37,41 @@ func NewContractDefinitionResource() resource.Resource {
 
 // ContractDefinitionResource defines the resource implementation.
 type ContractDefinitionResource struct {
-	client *contractdefinition.Client
+	connectors *EDCConnectors
 }
 
 // ContractDefinitionResourceModel describes the resource data model.
 type ContractDefinitionResourceModel struct {
-	Id               types.String `tfsdk:"id"`
-	AccessPolicyId   types.String `tfsdk:"access_policy_id"`
-	ContractPolicyId types.String `tfsdk:"contract_policy_id"`
-	Validity         types.Int64  `tfsdk:"validity"`
-	Criteria         []Criterion  `tfsdk:"criteria"`
+	Id                 types.String `tfsdk:"id"`
+	CreatedAt          types.Int64  `tfsdk:"created_at"`
+	AccessPolicyId     types.String `tfsdk:"access_policy_id"`
+	ContractPolicyId   types.String `tfsdk:"contract_policy_id"`
+	Validity           types.Int64  `tfsdk:"validity"`
+	Criteria           []Criterion  `tfsdk:"criteria"`
+	AssetIds           types.Set    `tfsdk:"asset_ids"`
+	SelectedAssetIds   types.Set    `tfsdk:"selected_asset_ids"`
+	CheckReferences    types.Bool   `tfsdk:"check_references"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	Connector          types.String `tfsdk:"connector"`
+}
+
+// contractDefinitionAPIPathAliases locates the fields of the contract
+// definition payloads of the JSON-LD management APIs.
+var contractDefinitionAPIPathAliases = apiPathAliases{
+	"assetsSelector": path.Root("criteria"),
 }
 
 type Criterion struct {
-	OperandLeft  types.String `tfsdk:"operand_left"`
-	Operator     types.String `tfsdk:"operator"`
-	OperandRight types.String `tfsdk:"operand_right"`
+	OperandLeft      types.String `tfsdk:"operand_left"`
+	Operator         types.String `tfsdk:"operator"`
+	OperandRight     types.String `tfsdk:"operand_right"`
+	OperandRightList types.List   `tfsdk:"operand_right_list"`
 }
 
+// criterionOperators are the operators of the criteria supported by the
+// connector.
+var criterionOperators = []string{"=", "!=", "in", "like", "ilike", "contains"}
+
 func (r *ContractDefinitionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_contract_definition"
 }
@@ -56,79 +83,310 @@ func (r *ContractDefinitionResource) Schema(ctx context.Context, req resource.Sc
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Contract definition identifier",
+				MarkdownDescription: "Contract definition identifier, generated by the connector when not set. Changing it replaces the contract definition.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"created_at": createdAtAttribute(),
 			"access_policy_id": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "Access policy identifier",
+				MarkdownDescription: "Access policy identifier. Changing it replaces the contract definition.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"contract_policy_id": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "Contract policy identifier",
+				MarkdownDescription: "Contract policy identifier. Changing it replaces the contract definition.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"validity": schema.Int64Attribute{
-				Required:            true,
-				MarkdownDescription: "Contract definition validity in seconds",
-				PlanModifiers:       []planmodifier.Int64{},
+				Required: true,
+				MarkdownDescription: "Contract definition validity in seconds. Ignored by the JSON-LD management APIs, which have no validity. " +
+					"Changing it replaces the contract definition.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 				Validators: []validator.Int64{
 					int64validator.AtLeast(1),
 				},
 			},
 			"criteria": CriteriaSchema(),
+			"asset_ids": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "Identifiers of the assets selected by the contract definition, " +
+					"sent as an `asset:prop:id in [...]` criterion. Conflicts with `criteria`. Changing it replaces the contract definition.",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ConflictsWith(path.MatchRoot("criteria")),
+				},
+			},
+			"selected_asset_ids": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: selectedAssetIdsDescription + " Assets created in the same apply are only included after the next refresh. " +
+					"Kept as last read, with a warning, when the connector cannot be reached, the assets cannot be listed or the criteria evaluated.",
+			},
+			"check_references": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Whether to check, when planning, that the policies of `access_policy_id` and `contract_policy_id`, " +
+					"and the assets of `asset_ids` and of the criteria selecting the asset id with `=` or `in`, exist on the connector. " +
+					"Policies and assets created in the same apply pass the check when their identifier is referenced from their `edc_policy` or `edc_asset` resource. " +
+					"Defaults to `false`.",
+			},
+			"connector":           connectorResourceAttribute(),
+			"deletion_protection": deletionProtectionAttribute(),
 		},
 	}
 }
 
+// selectedAssetIdsDescription documents the selected_asset_ids attributes.
+const selectedAssetIdsDescription = "Identifiers of the assets of the connector selected by the criteria, " +
+	"evaluated by the provider with the semantics of the connector. " +
+	"The `like` and `ilike` patterns match any sequence of characters with `%`. " +
+	"The assets are listed once per run of the provider."
+
 // CriteriaSchema returns the schema to use for tags.
 func CriteriaSchema() *schema.ListNestedAttribute {
 	return &schema.ListNestedAttribute{
-		Optional: true,
+		Optional:            true,
+		MarkdownDescription: "Criteria selecting the assets of the contract definition. Changing them replaces the contract definition.",
+		PlanModifiers: []planmodifier.List{
+			listplanmodifier.RequiresReplace(),
+		},
 		NestedObject: schema.NestedAttributeObject{
 			Attributes: map[string]schema.Attribute{
-				"operand_left":  schema.StringAttribute{Required: true},
-				"operator":      schema.StringAttribute{Required: true},
-				"operand_right": schema.StringAttribute{Optional: true},
+				"operand_left": schema.StringAttribute{Required: true},
+				"operator": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: "One of `" + strings.Join(criterionOperators, "`, `") + "`.",
+					Validators: []validator.String{
+						stringvalidator.OneOf(criterionOperators...),
+					},
+				},
+				"operand_right": schema.StringAttribute{
+					Optional: true,
+					Validators: []validator.String{
+						stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("operand_right_list")),
+					},
+				},
+				"operand_right_list": schema.ListAttribute{
+					Optional:            true,
+					ElementType:         types.StringType,
+					MarkdownDescription: "Values selected by the `in` operator, which requires them in place of `operand_right`.",
+				},
+			},
+			Validators: []validator.Object{
+				criterionValidator{},
 			},
 		},
 	}
 }
 
+// criterionValidator validates that the right operand of a criterion is a
+// list for the "in" operator only.
+type criterionValidator struct{}
+
+var _ validator.Object = criterionValidator{}
+
+func (v criterionValidator) Description(ctx context.Context) string {
+	return "operand_right_list must be set for the in operator only"
+}
+
+func (v criterionValidator) MarkdownDescription(ctx context.Context) string {
+	return "`operand_right_list` must be set for the `in` operator only"
+}
+
+func (v criterionValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	attributes := req.ConfigValue.Attributes()
+	operator, _ := attributes["operator"].(types.String)
+	operandRightList, _ := attributes["operand_right_list"].(types.List)
+	if operator.IsUnknown() || operator.IsNull() {
+		return
+	}
+
+	switch {
+	case operator.ValueString() == "in" && operandRightList.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			req.Path.AtName("operand_right_list"),
+			"Missing Criterion Operand",
+			"The in operator selects the values of operand_right_list, which must be set.",
+		)
+	case operator.ValueString() != "in" && !operandRightList.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			req.Path.AtName("operand_right_list"),
+			"Invalid Criterion Operand",
+			fmt.Sprintf("Only the in operator selects a list of values, the %s operator selects the value of operand_right.", operator.ValueString()),
+		)
+	}
+}
+
 func (r *ContractDefinitionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	cfg, ok := req.ProviderData.(*edc.Config)
+	connectors, ok := req.ProviderData.(*EDCConnectors)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *edc.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *EDCConnectors, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	client, err := contractdefinition.New(*cfg)
+	r.connectors = connectors
+}
+
+// ModifyPlan rejects destroying or replacing the contract definition when it
+// is protected against deletion, checks the policies and assets it references
+// when check_references is set, and shows the assets selected by the planned
+// criteria.
+func (r *ContractDefinitionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedDeletionProtection(ctx, req, resp,
+		path.Root("connector"), path.Root("id"), path.Root("access_policy_id"), path.Root("contract_policy_id"), path.Root("validity"),
+		path.Root("criteria"), path.Root("asset_ids"))
+
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() || r.connectors == nil || !plannedValuesKnown(req.Plan, "connector") {
+		return
+	}
+
+	var connectorName types.String
+	var checkRefs types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("connector"), &connectorName)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("check_references"), &checkRefs)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed, diags := r.connectors.managed(connectorName)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Planning does not require the connector to be reachable, unless the
+	// references are checked.
+	connector, err := managed.get(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to initiate contract definition client",
-			fmt.Sprintf("Client Error: %v", err),
-		)
+		if checkRefs.ValueBool() {
+			resp.Diagnostics.AddError("Unable to Prepare EDC API Client", connectorErrorDetail+err.Error())
+		} else {
+			resp.Diagnostics.AddWarning(
+				"Unable to Reach EDC Connector",
+				"The provider could not reach the connector to plan selected_asset_ids, which is left as planned by Terraform.\n\nError: "+err.Error(),
+			)
+		}
+		return
+	}
+
+	if checkRefs.ValueBool() {
+		references, diags := contractDefinitionReferences(ctx, req.Plan, connector.Prefixes.with(nil))
+		resp.Diagnostics.Append(diags...)
+		checkReferences(connector, references, func(kind, id string) bool {
+			return r.connectors.isPlanned(connectorName, kind, id)
+		}, &resp.Diagnostics)
+	}
+
+	// The selection is left unknown until the values selecting the assets
+	// are known.
+	if !plannedValuesKnown(req.Plan, "criteria", "asset_ids") {
 		return
 	}
 
-	r.client = client
+	var data *ContractDefinitionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	selected := connector.selectedAssets(data.criteria(), types.SetUnknown(types.StringType), &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("selected_asset_ids"), selected)...)
+}
+
+// plannedValuesKnown reports whether the planned values of the given
+// attributes are fully known.
+func plannedValuesKnown(plan tfsdk.Plan, names ...string) bool {
+	for _, name := range names {
+		value, _, err := tftypes.WalkAttributePath(plan.Raw, tftypes.NewAttributePath().WithAttributeName(name))
+		if value, ok := value.(tftypes.Value); err != nil || !ok || !value.IsFullyKnown() {
+			return false
+		}
+	}
+	return true
+}
+
+// contractDefinitionReferences returns the known identifiers of the policies
+// and assets referenced by a planned contract definition. Assets are
+// referenced by asset_ids, and by the criteria selecting the id of assets
+// with the = and in operators.
+func contractDefinitionReferences(ctx context.Context, plan tfsdk.Plan, prefixes jsonldPrefixes) ([]reference, diag.Diagnostics) {
+	var references []reference
+	var diags diag.Diagnostics
+
+	for _, name := range []string{"access_policy_id", "contract_policy_id"} {
+		var id types.String
+		diags.Append(plan.GetAttribute(ctx, path.Root(name), &id)...)
+		if !id.IsNull() && !id.IsUnknown() {
+			references = append(references, reference{path: path.Root(name), kind: policyReference, id: id.ValueString()})
+		}
+	}
+
+	var assetIds types.Set
+	diags.Append(plan.GetAttribute(ctx, path.Root("asset_ids"), &assetIds)...)
+	for _, value := range assetIds.Elements() {
+		if id, ok := value.(types.String); ok && !id.IsUnknown() {
+			references = append(references, reference{path: path.Root("asset_ids").AtSetValue(value), kind: assetReference, id: id.ValueString()})
+		}
+	}
+
+	var criteria types.List
+	diags.Append(plan.GetAttribute(ctx, path.Root("criteria"), &criteria)...)
+	for i, element := range criteria.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsUnknown() {
+			continue
+		}
+
+		var model Criterion
+		diags.Append(object.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+		if operator := model.Operator.ValueString(); model.OperandLeft.IsUnknown() || !prefixes.isAssetIdKey(model.OperandLeft.ValueString()) || operator != "=" && operator != "in" {
+			continue
+		}
+
+		criterionPath := path.Root("criteria").AtListIndex(i)
+		if !model.OperandRight.IsNull() && !model.OperandRight.IsUnknown() {
+			references = append(references, reference{path: criterionPath.AtName("operand_right"), kind: assetReference, id: model.OperandRight.ValueString()})
+		}
+		for j, value := range model.OperandRightList.Elements() {
+			if id, ok := value.(types.String); ok && !id.IsUnknown() {
+				references = append(references, reference{path: criterionPath.AtName("operand_right_list").AtListIndex(j), kind: assetReference, id: id.ValueString()})
+			}
+		}
+	}
+
+	return references, diags
 }
 
 func (r *ContractDefinitionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -141,15 +399,26 @@ func (r *ContractDefinitionResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
+	connector, diags := r.connectors.Connector(ctx, data.Connector)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	sdkObject := data.toSDKObject(ctx)
-	output, err := r.client.CreateContractDefinition(*sdkObject)
+	output, err := connector.ContractDefinitions.CreateContractDefinition(*sdkObject)
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create ContractDefinition, got error: %s", err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.Plan.Schema, contractDefinitionAPIPathAliases, "create ContractDefinition", err)
 		return
 	}
 
 	data.Id = types.StringValue(output.Id)
+	data.CreatedAt = types.Int64Value(output.CreatedAt)
+	if data.SelectedAssetIds.IsUnknown() {
+		data.SelectedAssetIds = connector.selectedAssets(sdkObject.Criteria, types.SetNull(types.StringType), &resp.Diagnostics)
+	}
 
 	// Write logs using the tflog package
 	tflog.Trace(ctx, "created a contract definition")
@@ -169,22 +438,60 @@ func (r *ContractDefinitionResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 
-	cd, err := r.client.GetContractDefinition(data.Id.ValueString())
+	connector, diags := r.connectors.Connector(ctx, data.Connector)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cd, err := connector.ContractDefinitions.GetContractDefinition(data.Id.ValueString())
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read contract definition, got error: %s", err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.State.Schema, contractDefinitionAPIPathAliases, "read contract definition", err)
 		return
 	}
 
+	data.CreatedAt = types.Int64Value(cd.CreatedAt)
 	data.AccessPolicyId = types.StringValue(cd.AccessPolicyId)
 	data.ContractPolicyId = types.StringValue(cd.ContractPolicyId)
-	data.Validity = types.Int64Value(cd.Validity)
-	data.Criteria = criteriaModel(cd.Criteria)
+	// The JSON-LD management APIs have no validity, the configured one is kept.
+	if cd.Validity != 0 {
+		data.Validity = types.Int64Value(cd.Validity)
+	}
+	data.Criteria = keepConfiguredOperands(criteriaModel(cd.Criteria), data.Criteria, connector.Prefixes.with(nil))
+	if !data.AssetIds.IsNull() {
+		// Keep the selected assets in asset_ids while the criteria are the
+		// ones it sends.
+		if assetIds, ok := selectedAssetIds(cd.Criteria); ok {
+			data.AssetIds = assetIds
+			data.Criteria = nil
+		} else {
+			data.AssetIds = types.SetNull(types.StringType)
+		}
+	}
+	data.SelectedAssetIds = connector.selectedAssets(cd.Criteria, data.SelectedAssetIds, &resp.Diagnostics)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// keepConfiguredOperands returns the criteria read from the connector with
+// the operand_left of the prior criteria when they denote the same IRI, as the
+// JSON-LD management APIs return the properties of the EDC namespace as
+// "asset:prop:*" whichever way they were configured.
+func keepConfiguredOperands(criteria, prior []Criterion, prefixes jsonldPrefixes) []Criterion {
+	for i := range criteria {
+		if i >= len(prior) || prior[i].OperandLeft.IsNull() || prior[i].OperandLeft.IsUnknown() {
+			continue
+		}
+		if prefixes.expand(prior[i].OperandLeft.ValueString()) == prefixes.expand(criteria[i].OperandLeft.ValueString()) {
+			criteria[i].OperandLeft = prior[i].OperandLeft
+		}
+	}
+	return criteria
+}
+
 func (r *ContractDefinitionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data *ContractDefinitionResourceModel
 
@@ -195,6 +502,17 @@ func (r *ContractDefinitionResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 
+	if data.SelectedAssetIds.IsUnknown() {
+		connector, diags := r.connectors.Connector(ctx, data.Connector)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.SelectedAssetIds = connector.selectedAssets(data.criteria(), types.SetNull(types.StringType), &resp.Diagnostics)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -205,41 +523,107 @@ func (r *ContractDefinitionResource) Delete(ctx context.Context, req resource.De
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
+	if resp.Diagnostics.HasError() || !checkDeletionProtection(data.DeletionProtection, &resp.Diagnostics) {
+		return
+	}
+
+	connector, diags := r.connectors.Connector(ctx, data.Connector)
+	resp.Diagnostics.Append(diags...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	err := r.client.DeleteContractDefinition(data.Id.ValueString())
+	err := connector.ContractDefinitions.DeleteContractDefinition(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete contract definition, got error: %s", err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.State.Schema, contractDefinitionAPIPathAliases, "delete contract definition", err)
 		return
 	}
 }
 
 func (r *ContractDefinitionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	r.connectors.ImportStatePassthroughID(ctx, req, resp)
 }
 
-func (r *ContractDefinitionResourceModel) toSDKObject(ctx context.Context) *contractdefinition.ContractDefinition {
+func (r *ContractDefinitionResourceModel) toSDKObject(ctx context.Context) *contractDefinition {
 	tflog.Debug(ctx, "transform tf object to sdk object", map[string]interface{}{
 		"tf object": r,
 	})
 
-	return &contractdefinition.ContractDefinition{
+	return &contractDefinition{
+		Id:               r.Id.ValueString(),
 		AccessPolicyId:   r.AccessPolicyId.ValueString(),
 		ContractPolicyId: r.ContractPolicyId.ValueString(),
 		Validity:         r.Validity.ValueInt64(),
-		Criteria:         criteriaToSDKObject(r.Criteria),
+		Criteria:         r.criteria(),
 	}
 }
 
-func criteriaToSDKObject(c []Criterion) []contractdefinition.Criterion {
-	var sdkObject []contractdefinition.Criterion
-	for _, criterion := range c {
-		sdkObject = append(sdkObject, contractdefinition.Criterion{
-			OperandLeft:  criterion.OperandLeft.ValueString(),
-			Operator:     criterion.Operator.ValueString(),
-			OperandRight: criterion.OperandRight.ValueString(),
+// criteria returns the criteria of the contract definition, which select the
+// assets of asset_ids when it is set.
+func (r *ContractDefinitionResourceModel) criteria() []criterion {
+	if r.AssetIds.IsNull() || r.AssetIds.IsUnknown() {
+		return criteriaToSDKObject(r.Criteria)
+	}
+
+	assetIds := make([]string, 0, len(r.AssetIds.Elements()))
+	for _, value := range r.AssetIds.Elements() {
+		if value, ok := value.(types.String); ok {
+			assetIds = append(assetIds, value.ValueString())
+		}
+	}
+	sort.Strings(assetIds)
+
+	return []criterion{{OperandLeft: assetIdOperandLeft, Operator: "in", OperandRight: assetIds}}
+}
+
+// assetIdOperandLeft is the operand selecting the id of assets.
+const assetIdOperandLeft = legacyAssetPropertyPrefix + "id"
+
+// selectedAssetIds returns the identifiers of the assets selected by criteria
+// sent for asset_ids, or false when the criteria select assets otherwise.
+func selectedAssetIds(criteria []criterion) (types.Set, bool) {
+	if len(criteria) != 1 || !defaultJSONLDPrefixes.isAssetIdKey(criteria[0].OperandLeft) {
+		return types.SetNull(types.StringType), false
+	}
+
+	var assetIds []string
+	switch operandRight := criteria[0].OperandRight.(type) {
+	case []string:
+		assetIds = operandRight
+	case string:
+		// The JSON-LD APIs compact the lists holding a single value.
+		assetIds = []string{operandRight}
+	default:
+		return types.SetNull(types.StringType), false
+	}
+	if operator := criteria[0].Operator; operator != "in" && (operator != "=" || len(assetIds) != 1) {
+		return types.SetNull(types.StringType), false
+	}
+
+	return stringSetValue(assetIds), true
+}
+
+func criteriaToSDKObject(c []Criterion) []criterion {
+	var sdkObject []criterion
+	for _, model := range c {
+		var operandRight interface{}
+		if !model.OperandRightList.IsNull() {
+			values := make([]string, 0, len(model.OperandRightList.Elements()))
+			for _, value := range model.OperandRightList.Elements() {
+				if value, ok := value.(types.String); ok {
+					values = append(values, value.ValueString())
+				}
+			}
+			operandRight = values
+		} else if !model.OperandRight.IsNull() {
+			operandRight = model.OperandRight.ValueString()
+		}
+
+		sdkObject = append(sdkObject, criterion{
+			OperandLeft:  model.OperandLeft.ValueString(),
+			Operator:     model.Operator.ValueString(),
+			OperandRight: operandRight,
 		},
 		)
 	}
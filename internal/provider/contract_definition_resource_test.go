@@ -1,9 +1,23 @@
 package provider
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/Think-iT-Labs/edc-connector-client-go/edc"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccContractDefinitionResource(t *testing.T) {
@@ -19,9 +33,12 @@ func TestAccContractDefinitionResource(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "contract_policy_id", "test"),
 					resource.TestCheckResourceAttr(resourceName, "validity", "600"),
 					resource.TestCheckResourceAttr(resourceName, "criteria.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "criteria.0.operator", "eq"),
+					resource.TestCheckResourceAttr(resourceName, "criteria.0.operator", "="),
 					resource.TestCheckResourceAttr(resourceName, "criteria.0.operand_left", "test"),
 					resource.TestCheckResourceAttr(resourceName, "criteria.0.operand_right", "test"),
+					resource.TestCheckResourceAttrSet(resourceName, "selected_asset_ids.#"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
 				),
 			},
 		},
@@ -36,7 +53,7 @@ resource "edc_contract_definition" "test" {
 	validity = 600
 	criteria = [
 		{
-			operator = "eq"
+			operator = "="
 			operand_left = "test"
 			operand_right = "test"
 		}
@@ -44,3 +61,364 @@ resource "edc_contract_definition" "test" {
 }
 `
 }
+
+func Test_criterionValidator(t *testing.T) {
+	attributeTypes := map[string]attr.Type{
+		"operand_left":       types.StringType,
+		"operator":           types.StringType,
+		"operand_right":      types.StringType,
+		"operand_right_list": types.ListType{ElemType: types.StringType},
+	}
+	newCriterion := func(operator types.String, operandRight types.String, operandRightList types.List) types.Object {
+		return types.ObjectValueMust(attributeTypes, map[string]attr.Value{
+			"operand_left":       types.StringValue("asset:prop:id"),
+			"operator":           operator,
+			"operand_right":      operandRight,
+			"operand_right_list": operandRightList,
+		})
+	}
+	list := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a"), types.StringValue("b")})
+
+	tests := []struct {
+		name          string
+		criterion     types.Object
+		expectedError bool
+	}{
+		{
+			name:      "equality",
+			criterion: newCriterion(types.StringValue("="), types.StringValue("a"), types.ListNull(types.StringType)),
+		},
+		{
+			name:      "in a list",
+			criterion: newCriterion(types.StringValue("in"), types.StringNull(), list),
+		},
+		{
+			name:          "in a string",
+			criterion:     newCriterion(types.StringValue("in"), types.StringValue("a,b"), types.ListNull(types.StringType)),
+			expectedError: true,
+		},
+		{
+			name:          "equality with a list",
+			criterion:     newCriterion(types.StringValue("like"), types.StringNull(), list),
+			expectedError: true,
+		},
+		{
+			name:      "unknown operator",
+			criterion: newCriterion(types.StringUnknown(), types.StringNull(), list),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &validator.ObjectResponse{}
+			criterionValidator{}.ValidateObject(context.Background(), validator.ObjectRequest{
+				Path:        path.Root("criteria").AtListIndex(0),
+				ConfigValue: tt.criterion,
+			}, resp)
+			assert.Equal(t, tt.expectedError, resp.Diagnostics.HasError(), resp.Diagnostics)
+		})
+	}
+}
+
+func Test_criteriaModel(t *testing.T) {
+	criteria := []criterion{
+		{OperandLeft: "asset:prop:id", Operator: "=", OperandRight: "a"},
+		{OperandLeft: "asset:prop:id", Operator: "in", OperandRight: []string{"a", "b"}},
+		{OperandLeft: "asset:prop:id", Operator: "in", OperandRight: "a"},
+		{OperandLeft: "asset:prop:id", Operator: "="},
+	}
+
+	models := criteriaModel(criteria)
+	assert.Equal(t, []Criterion{
+		{
+			OperandLeft:      types.StringValue("asset:prop:id"),
+			Operator:         types.StringValue("="),
+			OperandRight:     types.StringValue("a"),
+			OperandRightList: types.ListNull(types.StringType),
+		},
+		{
+			OperandLeft:      types.StringValue("asset:prop:id"),
+			Operator:         types.StringValue("in"),
+			OperandRight:     types.StringNull(),
+			OperandRightList: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a"), types.StringValue("b")}),
+		},
+		{
+			OperandLeft:      types.StringValue("asset:prop:id"),
+			Operator:         types.StringValue("in"),
+			OperandRight:     types.StringNull(),
+			OperandRightList: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a")}),
+		},
+		{
+			OperandLeft:      types.StringValue("asset:prop:id"),
+			Operator:         types.StringValue("="),
+			OperandRight:     types.StringNull(),
+			OperandRightList: types.ListNull(types.StringType),
+		},
+	}, models)
+
+	assert.Equal(t, []criterion{
+		{OperandLeft: "asset:prop:id", Operator: "=", OperandRight: "a"},
+		{OperandLeft: "asset:prop:id", Operator: "in", OperandRight: []string{"a", "b"}},
+		{OperandLeft: "asset:prop:id", Operator: "in", OperandRight: []string{"a"}},
+		{OperandLeft: "asset:prop:id", Operator: "="},
+	}, criteriaToSDKObject(models))
+}
+
+func TestContractDefinitionResourceModel_criteria(t *testing.T) {
+	model := ContractDefinitionResourceModel{
+		AssetIds: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("b"), types.StringValue("a")}),
+	}
+	assert.Equal(t, []criterion{
+		{OperandLeft: "asset:prop:id", Operator: "in", OperandRight: []string{"a", "b"}},
+	}, model.criteria())
+
+	model = ContractDefinitionResourceModel{
+		AssetIds: types.SetNull(types.StringType),
+		Criteria: []Criterion{{
+			OperandLeft:      types.StringValue("asset:prop:name"),
+			Operator:         types.StringValue("="),
+			OperandRight:     types.StringValue("a"),
+			OperandRightList: types.ListNull(types.StringType),
+		}},
+	}
+	assert.Equal(t, []criterion{
+		{OperandLeft: "asset:prop:name", Operator: "=", OperandRight: "a"},
+	}, model.criteria())
+}
+
+func TestContractDefinitionResourceModel_toSDKObject_id(t *testing.T) {
+	model := ContractDefinitionResourceModel{Id: types.StringValue("cd-1"), AssetIds: types.SetNull(types.StringType)}
+	assert.Equal(t, "cd-1", model.toSDKObject(context.Background()).Id)
+
+	// The connector generates the id when it is not set.
+	model.Id = types.StringUnknown()
+	assert.Equal(t, "", model.toSDKObject(context.Background()).Id)
+}
+
+func Test_selectedAssetIds(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria []criterion
+		expected []string
+	}{
+		{
+			name:     "in",
+			criteria: []criterion{{OperandLeft: "asset:prop:id", Operator: "in", OperandRight: []string{"a", "b"}}},
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "compacted single value",
+			criteria: []criterion{{OperandLeft: "asset:prop:id", Operator: "in", OperandRight: "a"}},
+			expected: []string{"a"},
+		},
+		{
+			name:     "equality",
+			criteria: []criterion{{OperandLeft: "https://w3id.org/edc/v0.0.1/ns/id", Operator: "=", OperandRight: "a"}},
+			expected: []string{"a"},
+		},
+		{
+			name:     "other property",
+			criteria: []criterion{{OperandLeft: "asset:prop:name", Operator: "in", OperandRight: []string{"a"}}},
+		},
+		{
+			name:     "other operator",
+			criteria: []criterion{{OperandLeft: "asset:prop:id", Operator: "like", OperandRight: "a%"}},
+		},
+		{
+			name: "several criteria",
+			criteria: []criterion{
+				{OperandLeft: "asset:prop:id", Operator: "in", OperandRight: []string{"a"}},
+				{OperandLeft: "asset:prop:name", Operator: "=", OperandRight: "a"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assetIds, ok := selectedAssetIds(tt.criteria)
+			assert.Equal(t, tt.expected != nil, ok)
+			if tt.expected == nil {
+				assert.True(t, assetIds.IsNull())
+				return
+			}
+
+			var values []string
+			assert.False(t, assetIds.ElementsAs(context.Background(), &values, false).HasError())
+			assert.ElementsMatch(t, tt.expected, values)
+		})
+	}
+}
+
+func TestContractDefinitionResource_ModifyPlan_unreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	address := server.URL + "/management"
+	connector, err := newManagedConnector(newStaticTokenSource("1234"), edc.Addresses{
+		Default:    &address,
+		Management: &address,
+		Protocol:   &address,
+		Public:     &address,
+		Control:    &address,
+	}, managementAPIAuto)
+	assert.NoError(t, err)
+	r := &ContractDefinitionResource{connectors: &EDCConnectors{defaultConnector: connector}}
+
+	for _, checkReferences := range []bool{false, true} {
+		config := newTestResourceConfig(r, map[string]tftypes.Value{
+			"access_policy_id":   tftypes.NewValue(tftypes.String, "access"),
+			"contract_policy_id": tftypes.NewValue(tftypes.String, "contract"),
+			"check_references":   tftypes.NewValue(tftypes.Bool, checkReferences),
+			"selected_asset_ids": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, tftypes.UnknownValue),
+		})
+		plan := tfsdk.Plan{Schema: config.Schema, Raw: config.Raw}
+		resp := &fwresource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(context.Background(), fwresource.ModifyPlanRequest{Plan: plan}, resp)
+
+		if checkReferences {
+			assert.True(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		} else {
+			assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.Len(t, resp.Diagnostics.Warnings(), 1)
+			assert.True(t, resp.Plan.Raw.Equal(plan.Raw))
+		}
+	}
+}
+
+func TestContractDefinitionResource_Schema_assetIdsReplace(t *testing.T) {
+	schemaResp := &fwresource.SchemaResponse{}
+	NewContractDefinitionResource().Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+
+	// The connector is not called on updates, so changing the selected
+	// assets must replace the contract definition.
+	assetIds, ok := schemaResp.Schema.Attributes["asset_ids"].(schema.SetAttribute)
+	assert.True(t, ok)
+	resp := &planmodifier.SetResponse{}
+	for _, modifier := range assetIds.PlanModifiers {
+		modifier.PlanModifySet(context.Background(), planmodifier.SetRequest{
+			State:       tfsdk.State{Raw: tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})},
+			Plan:        tfsdk.Plan{Raw: tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})},
+			StateValue:  stringSetValue([]string{"asset-1"}),
+			PlanValue:   stringSetValue([]string{"asset-2"}),
+			ConfigValue: stringSetValue([]string{"asset-2"}),
+		}, resp)
+	}
+	assert.True(t, resp.RequiresReplace)
+}
+
+func TestContractDefinitionResource_Read_operandLeft(t *testing.T) {
+	ctx := context.Background()
+	server, _, _ := newJSONLDTestServer(t, http.StatusOK, `{
+		"@id": "cd-1",
+		"edc:accessPolicyId": "access",
+		"edc:contractPolicyId": "contract",
+		"edc:assetsSelector": [
+			{"@type": "edc:Criterion", "edc:operandLeft": "https://w3id.org/edc/v0.0.1/ns/id", "edc:operator": "=", "edc:operandRight": "asset-1"},
+			{"@type": "edc:Criterion", "edc:operandLeft": "https://w3id.org/edc/v0.0.1/ns/name", "edc:operator": "=", "edc:operandRight": "Asset"},
+			{"@type": "edc:Criterion", "edc:operandLeft": "https://w3id.org/edc/v0.0.1/ns/version", "edc:operator": "=", "edc:operandRight": "1"}
+		]
+	}`)
+	connector := newTestConnector(t, server.URL, managementAPIV2)
+	r := &ContractDefinitionResource{connectors: &EDCConnectors{defaultConnector: &managedConnector{current: connector}}}
+
+	config := newTestResourceConfig(r, nil)
+	criteriaType := config.Raw.Type().(tftypes.Object).AttributeTypes["criteria"].(tftypes.List)
+	criterionType := criteriaType.ElementType.(tftypes.Object)
+	newCriterion := func(operandLeft, operandRight string) tftypes.Value {
+		return newTestObjectValue(criterionType, map[string]tftypes.Value{
+			"operand_left":  tftypes.NewValue(tftypes.String, operandLeft),
+			"operator":      tftypes.NewValue(tftypes.String, "="),
+			"operand_right": tftypes.NewValue(tftypes.String, operandRight),
+		})
+	}
+	prior := newTestResourceConfig(r, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, "cd-1"),
+		"criteria": tftypes.NewValue(criteriaType, []tftypes.Value{
+			newCriterion("https://w3id.org/edc/v0.0.1/ns/id", "asset-1"),
+			newCriterion("asset:prop:name", "Asset"),
+		}),
+	})
+
+	resp := &fwresource.ReadResponse{State: tfsdk.State{Schema: prior.Schema, Raw: prior.Raw}}
+	r.Read(ctx, fwresource.ReadRequest{State: tfsdk.State{Schema: prior.Schema, Raw: prior.Raw}}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var data ContractDefinitionResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Len(t, data.Criteria, 3)
+	assert.Equal(t, types.StringValue("https://w3id.org/edc/v0.0.1/ns/id"), data.Criteria[0].OperandLeft)
+	assert.Equal(t, types.StringValue("asset:prop:name"), data.Criteria[1].OperandLeft)
+	assert.Equal(t, types.StringValue("asset:prop:version"), data.Criteria[2].OperandLeft)
+}
+
+func TestContractDefinitionResource_PlanResourceChange_replace(t *testing.T) {
+	r := NewContractDefinitionResource()
+	criteriaType := newTestResourceConfig(r, nil).Raw.Type().(tftypes.Object).AttributeTypes["criteria"].(tftypes.List)
+	criteria := func(operandRight string) tftypes.Value {
+		return tftypes.NewValue(criteriaType, []tftypes.Value{
+			newTestObjectValue(criteriaType.ElementType.(tftypes.Object), map[string]tftypes.Value{
+				"operand_left":  tftypes.NewValue(tftypes.String, "asset:prop:id"),
+				"operator":      tftypes.NewValue(tftypes.String, "="),
+				"operand_right": tftypes.NewValue(tftypes.String, operandRight),
+			}),
+		})
+	}
+	prior := map[string]tftypes.Value{
+		"id":                 tftypes.NewValue(tftypes.String, "cd-1"),
+		"access_policy_id":   tftypes.NewValue(tftypes.String, "access"),
+		"contract_policy_id": tftypes.NewValue(tftypes.String, "contract"),
+		"validity":           tftypes.NewValue(tftypes.Number, 3600),
+		"criteria":           criteria("asset-1"),
+	}
+	with := func(name string, value tftypes.Value) map[string]tftypes.Value {
+		values := map[string]tftypes.Value{name: value}
+		for k, v := range prior {
+			if k != name {
+				values[k] = v
+			}
+		}
+		return values
+	}
+
+	tests := []struct {
+		name            string
+		proposed        map[string]tftypes.Value
+		expectedReplace []string
+	}{
+		{
+			name:     "unchanged",
+			proposed: prior,
+		},
+		{
+			name:            "access policy",
+			proposed:        with("access_policy_id", tftypes.NewValue(tftypes.String, "access-2")),
+			expectedReplace: []string{"access_policy_id"},
+		},
+		{
+			name:            "contract policy",
+			proposed:        with("contract_policy_id", tftypes.NewValue(tftypes.String, "contract-2")),
+			expectedReplace: []string{"contract_policy_id"},
+		},
+		{
+			name:            "validity",
+			proposed:        with("validity", tftypes.NewValue(tftypes.Number, 7200)),
+			expectedReplace: []string{"validity"},
+		},
+		{
+			name:            "criteria",
+			proposed:        with("criteria", criteria("asset-2")),
+			expectedReplace: []string{"criteria"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := planTestResource(t, "edc_contract_definition", r, prior, tt.proposed, "selected_asset_ids")
+			assert.Empty(t, resp.Diagnostics)
+
+			var expectedReplace []*tftypes.AttributePath
+			for _, name := range tt.expectedReplace {
+				expectedReplace = append(expectedReplace, tftypes.NewAttributePath().WithAttributeName(name))
+			}
+			assert.ElementsMatch(t, expectedReplace, resp.RequiresReplace)
+		})
+	}
+}
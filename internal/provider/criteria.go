@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// assetCache holds the assets of a connector, listed at most once per run of
+// the provider to evaluate the criteria of every contract definition. The
+// asset resource invalidates it when it creates or deletes an asset.
+type assetCache struct {
+	mu     sync.Mutex
+	listed bool
+	assets []assetOutput
+	err    error
+}
+
+// list returns the assets of the connector, listing them on the first call.
+func (c *assetCache) list(api assetsAPI) ([]assetOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.listed {
+		c.assets, c.err = api.ListAssets()
+		c.listed = true
+	}
+	return c.assets, c.err
+}
+
+// invalidate makes the next call to list the assets again.
+func (c *assetCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.listed = false
+	c.assets = nil
+	c.err = nil
+}
+
+// selectedAssets returns the identifiers of the connector assets selected by
+// the criteria of a contract definition. It returns unavailable, with a
+// warning, when the assets cannot be listed or the criteria evaluated.
+func (c *EDCConnector) selectedAssets(criteria []criterion, unavailable types.Set, diags *diag.Diagnostics) types.Set {
+	assets, err := c.assetCache.list(c.Assets)
+	if err == nil {
+		var assetIds []string
+		if assetIds, err = selectAssets(criteria, assets, c.Prefixes.with(nil)); err == nil {
+			return stringSetValue(assetIds)
+		}
+	}
+
+	diags.AddWarning(
+		"Unable to Evaluate Criteria",
+		"The provider could not determine the assets selected by the contract definition, selected_asset_ids is not updated.\n\n"+
+			"Error: "+err.Error(),
+	)
+	return unavailable
+}
+
+// selectAssets returns the sorted identifiers of the assets selected by all
+// the criteria, evaluated as the connector does. Operators the provider
+// cannot evaluate fail the selection.
+func selectAssets(criteria []criterion, assets []assetOutput, prefixes jsonldPrefixes) ([]string, error) {
+	selected := []string{}
+	for _, asset := range assets {
+		properties := prefixes.expandKeys(asset.Properties)
+		if _, ok := properties[edcNamespace+"id"]; !ok {
+			properties[edcNamespace+"id"] = asset.Id
+		}
+
+		matches := true
+		for _, criterion := range criteria {
+			match, err := criterion.matches(properties, prefixes)
+			if err != nil {
+				return nil, err
+			}
+			if !match {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			selected = append(selected, asset.Id)
+		}
+	}
+
+	sort.Strings(selected)
+	return selected, nil
+}
+
+// matches reports whether the criterion selects the asset holding the
+// properties, keyed by their IRI.
+func (c criterion) matches(properties map[string]interface{}, prefixes jsonldPrefixes) (bool, error) {
+	property, found := properties[prefixes.expand(c.OperandLeft)]
+
+	switch c.Operator {
+	case "=":
+		return found && stringValue(property) == stringValue(c.OperandRight), nil
+	case "!=":
+		return !found || stringValue(property) != stringValue(c.OperandRight), nil
+	case "in":
+		if !found {
+			return false, nil
+		}
+		for _, value := range operandRightValues(c.OperandRight) {
+			if stringValue(property) == value {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "like", "ilike":
+		if !found {
+			return false, nil
+		}
+		pattern, err := likePattern(stringValue(c.OperandRight), c.Operator == "ilike")
+		if err != nil {
+			return false, err
+		}
+		return pattern.MatchString(stringValue(property)), nil
+	case "contains":
+		values, ok := property.([]interface{})
+		if !ok {
+			return false, nil
+		}
+		for _, value := range values {
+			if reflect.DeepEqual(value, c.OperandRight) || stringValue(value) == stringValue(c.OperandRight) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return false, fmt.Errorf("the %q operator cannot be evaluated by the provider", c.Operator)
+}
+
+// selectsAssetId reports whether the criterion selects the asset by its id,
+// with the = or in operators.
+func (c criterion) selectsAssetId(assetId string, prefixes jsonldPrefixes) bool {
+	if !prefixes.isAssetIdKey(c.OperandLeft) || c.Operator != "=" && c.Operator != "in" {
+		return false
+	}
+	for _, value := range operandRightValues(c.OperandRight) {
+		if value == assetId {
+			return true
+		}
+	}
+	return false
+}
+
+// operandRightValues returns the values selected by the "in" operator. A
+// single value is compacted from a list by the JSON-LD APIs.
+func operandRightValues(operandRight interface{}) []string {
+	switch operandRight := operandRight.(type) {
+	case []string:
+		return operandRight
+	case nil:
+		return nil
+	}
+	return []string{stringValue(operandRight)}
+}
+
+// likePattern returns the regular expression of a "like" pattern, in which
+// "%" matches any sequence of characters.
+func likePattern(pattern string, caseInsensitive bool) (*regexp.Regexp, error) {
+	parts := strings.Split(pattern, "%")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+
+	expression := "^" + strings.Join(parts, ".*") + "$"
+	if caseInsensitive {
+		expression = "(?i)" + expression
+	}
+	return regexp.Compile("(?s)" + expression)
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_selectAssets(t *testing.T) {
+	assets := []assetOutput{
+		{Id: "orders-2023", Properties: map[string]interface{}{"asset:prop:name": "Orders 2023", "dct:type": "dataset", "keywords": []interface{}{"sales"}}},
+		{Id: "orders-2024", Properties: map[string]interface{}{"asset:prop:name": "Orders 2024", "http://purl.org/dc/terms/type": "dataset"}},
+		{Id: "orders_archive", Properties: map[string]interface{}{"asset:prop:name": "orders archive", "asset:prop:version": 2.0}},
+	}
+	prefixes := defaultJSONLDPrefixes.with(map[string]string{"dct": "http://purl.org/dc/terms/"})
+
+	tests := []struct {
+		name     string
+		criteria []criterion
+		expected []string
+		err      string
+	}{
+		{
+			name:     "no criteria",
+			expected: []string{"orders-2023", "orders-2024", "orders_archive"},
+		},
+		{
+			name:     "equal id",
+			criteria: []criterion{{OperandLeft: "asset:prop:id", Operator: "=", OperandRight: "orders-2024"}},
+			expected: []string{"orders-2024"},
+		},
+		{
+			name:     "equal expanded key",
+			criteria: []criterion{{OperandLeft: "dct:type", Operator: "=", OperandRight: "dataset"}},
+			expected: []string{"orders-2023", "orders-2024"},
+		},
+		{
+			name:     "equal number",
+			criteria: []criterion{{OperandLeft: "version", Operator: "=", OperandRight: "2"}},
+			expected: []string{"orders_archive"},
+		},
+		{
+			name:     "not equal",
+			criteria: []criterion{{OperandLeft: "dct:type", Operator: "!=", OperandRight: "dataset"}},
+			expected: []string{"orders_archive"},
+		},
+		{
+			name:     "in list",
+			criteria: []criterion{{OperandLeft: "https://w3id.org/edc/v0.0.1/ns/id", Operator: "in", OperandRight: []string{"orders-2023", "orders_archive", "unknown"}}},
+			expected: []string{"orders-2023", "orders_archive"},
+		},
+		{
+			name:     "in compacted list",
+			criteria: []criterion{{OperandLeft: "edc:id", Operator: "in", OperandRight: "orders-2023"}},
+			expected: []string{"orders-2023"},
+		},
+		{
+			name:     "like",
+			criteria: []criterion{{OperandLeft: "name", Operator: "like", OperandRight: "Orders %"}},
+			expected: []string{"orders-2023", "orders-2024"},
+		},
+		{
+			name:     "like quotes the pattern",
+			criteria: []criterion{{OperandLeft: "asset:prop:id", Operator: "like", OperandRight: "orders.%"}},
+			expected: []string{},
+		},
+		{
+			name:     "ilike",
+			criteria: []criterion{{OperandLeft: "name", Operator: "ilike", OperandRight: "orders%"}},
+			expected: []string{"orders-2023", "orders-2024", "orders_archive"},
+		},
+		{
+			name:     "contains",
+			criteria: []criterion{{OperandLeft: "keywords", Operator: "contains", OperandRight: "sales"}},
+			expected: []string{"orders-2023"},
+		},
+		{
+			name: "all criteria",
+			criteria: []criterion{
+				{OperandLeft: "name", Operator: "like", OperandRight: "Orders%"},
+				{OperandLeft: "asset:prop:id", Operator: "!=", OperandRight: "orders-2023"},
+			},
+			expected: []string{"orders-2024"},
+		},
+		{
+			name:     "unsupported operator",
+			criteria: []criterion{{OperandLeft: "name", Operator: "~", OperandRight: "Orders"}},
+			err:      `the "~" operator cannot be evaluated by the provider`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := selectAssets(tt.criteria, assets, prefixes)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, selected)
+		})
+	}
+}
+
+func TestEDCConnector_selectedAssets_cache(t *testing.T) {
+	listings := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		listings++
+		_, _ = w.Write([]byte(`[{"@id": "asset-1", "properties": {}}]`))
+	}))
+	t.Cleanup(server.Close)
+	connector := newTestConnector(t, server.URL, managementAPIV3)
+
+	var diags diag.Diagnostics
+	for i := 0; i < 2; i++ {
+		selected := connector.selectedAssets(nil, types.SetNull(types.StringType), &diags)
+		assert.Equal(t, stringSetValue([]string{"asset-1"}), selected)
+	}
+	assert.Equal(t, 1, listings)
+
+	connector.assetCache.invalidate()
+	connector.selectedAssets(nil, types.SetNull(types.StringType), &diags)
+	assert.Equal(t, 2, listings)
+	assert.Empty(t, diags)
+
+	server.Close()
+	connector = newTestConnector(t, server.URL, managementAPIV3)
+	selected := connector.selectedAssets(nil, types.SetUnknown(types.StringType), &diags)
+	assert.True(t, selected.IsUnknown())
+	assert.Len(t, diags.Warnings(), 1)
+}
@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// deletionProtectionAttribute returns the schema of the attribute protecting
+// a resource against deletion.
+func deletionProtectionAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional: true,
+		MarkdownDescription: "Whether Terraform is prevented from deleting, or replacing, the resource. Defaults to `false`. " +
+			"Plans destroying or replacing the resource fail while it is set. Set it to `false` and apply before destroying the resource.",
+	}
+}
+
+// forceDeleteAttribute returns the schema of the attribute cascading the
+// deletion of a policy or an asset to the contract definitions referencing
+// it.
+func forceDeleteAttribute(kind, blockers string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional: true,
+		MarkdownDescription: fmt.Sprintf("Whether deleting the %s first deletes the contract definitions %s, including the ones not managed by Terraform. "+
+			"Defaults to `false`, in which case the deletion fails while such contract definitions exist.", kind, blockers),
+	}
+}
+
+// checkDeletionProtection reports an error, and returns false, when the prior
+// state protects the resource against deletion.
+func checkDeletionProtection(deletionProtection types.Bool, diags *diag.Diagnostics) bool {
+	if !deletionProtection.ValueBool() {
+		return true
+	}
+
+	diags.AddAttributeError(
+		path.Root("deletion_protection"),
+		"Resource Protected Against Deletion",
+		"The resource has deletion_protection set, which prevents Terraform from deleting or replacing it. "+
+			"Set deletion_protection to false and apply before deleting the resource.",
+	)
+	return false
+}
+
+// checkPlannedDeletionProtection reports an error when the plan destroys a
+// resource protected against deletion, or replaces it. The replacements
+// required by attribute plan modifiers are not visible to ModifyPlan, so the
+// attributes at replacePaths, whose changes replace the resource, are compared
+// with the prior state instead.
+func checkPlannedDeletionProtection(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, replacePaths ...path.Path) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var deletionProtection types.Bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)...)
+
+	if resp.Diagnostics.HasError() || !deletionProtection.ValueBool() {
+		return
+	}
+
+	replaced := req.Plan.Raw.IsNull() || len(resp.RequiresReplace) != 0
+	for _, p := range replacePaths {
+		if replaced {
+			break
+		}
+
+		var planned, prior attr.Value
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, p, &planned)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, p, &prior)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		replaced = !planned.Equal(prior)
+	}
+
+	if replaced {
+		checkDeletionProtection(deletionProtection, &resp.Diagnostics)
+	}
+}
+
+// policyDeletionBlockers returns the contract definitions using the policy as
+// access or contract policy.
+func policyDeletionBlockers(connector *EDCConnector, policyId string) ([]contractDefinition, error) {
+	definitions, err := connector.ContractDefinitions.ListContractDefinitions()
+	if err != nil {
+		return nil, err
+	}
+
+	var blockers []contractDefinition
+	for _, cd := range definitions {
+		if cd.AccessPolicyId == policyId || cd.ContractPolicyId == policyId {
+			blockers = append(blockers, cd)
+		}
+	}
+	return blockers, nil
+}
+
+// assetDeletionBlockers returns the contract definitions selecting the asset
+// by its id, and the contract agreements granting access to it.
+func assetDeletionBlockers(connector *EDCConnector, assetId string) ([]contractDefinition, []contractAgreement, error) {
+	definitions, err := connector.ContractDefinitions.ListContractDefinitions()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prefixes := connector.Prefixes.with(nil)
+	var blockers []contractDefinition
+	for _, cd := range definitions {
+		for _, criterion := range cd.Criteria {
+			if criterion.selectsAssetId(assetId, prefixes) {
+				blockers = append(blockers, cd)
+				break
+			}
+		}
+	}
+
+	agreements, err := connector.ContractAgreements.ListContractAgreements()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var agreementBlockers []contractAgreement
+	for _, agreement := range agreements {
+		if agreement.AssetId == assetId {
+			agreementBlockers = append(agreementBlockers, agreement)
+		}
+	}
+	return blockers, agreementBlockers, nil
+}
+
+// clearDeletionBlockers deletes the contract definitions blocking the
+// deletion of a policy or an asset when force is set, and reports them
+// otherwise. Contract agreements cannot be deleted: they are always reported,
+// and nothing is deleted while they exist. It returns false when the deletion
+// must not proceed.
+func clearDeletionBlockers(ctx context.Context, connector *EDCConnector, kind, id string, definitions []contractDefinition, agreements []contractAgreement, force bool, diags *diag.Diagnostics) bool {
+	if force && len(agreements) == 0 {
+		for _, cd := range definitions {
+			tflog.Info(ctx, "deleting a contract definition referencing the "+kind, map[string]interface{}{
+				"contract_definition_id": cd.Id,
+			})
+			if err := connector.ContractDefinitions.DeleteContractDefinition(cd.Id); err != nil {
+				diags.AddError(
+					"Unable to Delete Referencing Contract Definition",
+					fmt.Sprintf("The provider could not delete the contract definition %q referencing the %s %q.\n\nError: %s", cd.Id, kind, id, err),
+				)
+				return false
+			}
+		}
+		definitions = nil
+	}
+
+	if len(definitions) == 0 && len(agreements) == 0 {
+		return true
+	}
+
+	var blockers []string
+	if len(definitions) != 0 {
+		ids := make([]string, len(definitions))
+		for i, cd := range definitions {
+			ids[i] = fmt.Sprintf("%q", cd.Id)
+		}
+		blockers = append(blockers, "Contract definitions: "+strings.Join(ids, ", "))
+	}
+	if len(agreements) != 0 {
+		ids := make([]string, len(agreements))
+		for i, agreement := range agreements {
+			ids[i] = fmt.Sprintf("%q", agreement.Id)
+		}
+		blockers = append(blockers, "Contract agreements: "+strings.Join(ids, ", "))
+	}
+
+	detail := fmt.Sprintf("The %s %q is still referenced on the connector:\n\n%s", kind, id, strings.Join(blockers, "\n"))
+	if len(agreements) != 0 {
+		detail += "\n\nContract agreements cannot be deleted."
+	} else {
+		detail += "\n\nDelete the contract definitions first, or set force_delete to delete them with the " + kind + "."
+	}
+	diags.AddError("Deletion Blocked by References", detail)
+	return false
+}
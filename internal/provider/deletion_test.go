@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+// newDeletionTestServer serves the contract definitions and agreements of
+// the v3 management API, and records the deleted contract definitions.
+func newDeletionTestServer(t *testing.T) (*httptest.Server, *[]string) {
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /management/v3/contractdefinitions/request":
+			_, _ = w.Write([]byte(`[
+				{"@id": "cd-policy", "accessPolicyId": "policy-1", "contractPolicyId": "other", "assetsSelector": []},
+				{"@id": "cd-asset", "accessPolicyId": "other", "contractPolicyId": "policy-1", "assetsSelector": [
+					{"operandLeft": "https://w3id.org/edc/v0.0.1/ns/id", "operator": "in", "operandRight": ["asset-1", "asset-2"]}
+				]},
+				{"@id": "cd-pattern", "accessPolicyId": "other", "contractPolicyId": "other", "assetsSelector": [
+					{"operandLeft": "https://w3id.org/edc/v0.0.1/ns/id", "operator": "like", "operandRight": "asset-%"}
+				]}
+			]`))
+		case "POST /management/v3/contractagreements/request":
+			_, _ = w.Write([]byte(`[{"@id": "agreement-1", "assetId": "asset-2"}]`))
+		case "DELETE /management/v3/contractdefinitions/cd-policy", "DELETE /management/v3/contractdefinitions/cd-asset":
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &deleted
+}
+
+func Test_policyDeletionBlockers(t *testing.T) {
+	server, _ := newDeletionTestServer(t)
+	connector := newTestConnector(t, server.URL, managementAPIV3)
+
+	definitions, err := policyDeletionBlockers(connector, "policy-1")
+	assert.NoError(t, err)
+	assert.Len(t, definitions, 2)
+	assert.Equal(t, "cd-policy", definitions[0].Id)
+	assert.Equal(t, "cd-asset", definitions[1].Id)
+
+	definitions, err = policyDeletionBlockers(connector, "policy-2")
+	assert.NoError(t, err)
+	assert.Empty(t, definitions)
+}
+
+func Test_assetDeletionBlockers(t *testing.T) {
+	server, _ := newDeletionTestServer(t)
+	connector := newTestConnector(t, server.URL, managementAPIV3)
+
+	definitions, agreements, err := assetDeletionBlockers(connector, "asset-1")
+	assert.NoError(t, err)
+	assert.Len(t, definitions, 1)
+	assert.Equal(t, "cd-asset", definitions[0].Id)
+	assert.Empty(t, agreements)
+
+	definitions, agreements, err = assetDeletionBlockers(connector, "asset-2")
+	assert.NoError(t, err)
+	assert.Len(t, definitions, 1)
+	assert.Equal(t, []contractAgreement{{Id: "agreement-1", AssetId: "asset-2"}}, agreements)
+}
+
+func Test_clearDeletionBlockers(t *testing.T) {
+	definitions := []contractDefinition{{Id: "cd-policy"}, {Id: "cd-asset"}}
+	agreements := []contractAgreement{{Id: "agreement-1", AssetId: "asset-2"}}
+
+	tests := []struct {
+		name          string
+		definitions   []contractDefinition
+		agreements    []contractAgreement
+		force         bool
+		expected      bool
+		expectedError string
+		deleted       []string
+	}{
+		{
+			name:     "no blockers",
+			expected: true,
+		},
+		{
+			name:        "blocked by contract definitions",
+			definitions: definitions,
+			expectedError: "The policy \"policy-1\" is still referenced on the connector:\n\n" +
+				"Contract definitions: \"cd-policy\", \"cd-asset\"\n\n" +
+				"Delete the contract definitions first, or set force_delete to delete them with the policy.",
+		},
+		{
+			name:        "forced",
+			definitions: definitions,
+			force:       true,
+			expected:    true,
+			deleted:     []string{"/management/v3/contractdefinitions/cd-policy", "/management/v3/contractdefinitions/cd-asset"},
+		},
+		{
+			name:        "blocked by contract agreements",
+			definitions: definitions,
+			agreements:  agreements,
+			force:       true,
+			expectedError: "The policy \"policy-1\" is still referenced on the connector:\n\n" +
+				"Contract definitions: \"cd-policy\", \"cd-asset\"\n" +
+				"Contract agreements: \"agreement-1\"\n\n" +
+				"Contract agreements cannot be deleted.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, deleted := newDeletionTestServer(t)
+			connector := newTestConnector(t, server.URL, managementAPIV3)
+
+			var diags diag.Diagnostics
+			proceed := clearDeletionBlockers(context.Background(), connector, "policy", "policy-1", tt.definitions, tt.agreements, tt.force, &diags)
+			assert.Equal(t, tt.expected, proceed)
+			assert.Equal(t, tt.deleted, *deleted)
+			if tt.expectedError == "" {
+				assert.False(t, diags.HasError(), diags)
+			} else {
+				assert.Len(t, diags.Errors(), 1)
+				assert.Equal(t, tt.expectedError, diags.Errors()[0].Detail())
+			}
+		})
+	}
+}
+
+func Test_checkDeletionProtection(t *testing.T) {
+	var diags diag.Diagnostics
+	assert.True(t, checkDeletionProtection(types.BoolNull(), &diags))
+	assert.True(t, checkDeletionProtection(types.BoolValue(false), &diags))
+	assert.False(t, diags.HasError())
+
+	assert.False(t, checkDeletionProtection(types.BoolValue(true), &diags))
+	assert.Equal(t, []string{"deletion_protection"}, getErrorPaths(diags.Errors()))
+}
+
+func Test_checkPlannedDeletionProtection(t *testing.T) {
+	protected := map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, "id-1"),
+		"deletion_protection": tftypes.NewValue(tftypes.Bool, true),
+	}
+	with := func(values map[string]tftypes.Value, name string, value tftypes.Value) map[string]tftypes.Value {
+		updated := map[string]tftypes.Value{name: value}
+		for k, v := range values {
+			if k != name {
+				updated[k] = v
+			}
+		}
+		return updated
+	}
+	assetIds := func(ids ...string) tftypes.Value {
+		elements := make([]tftypes.Value, len(ids))
+		for i, id := range ids {
+			elements[i] = tftypes.NewValue(tftypes.String, id)
+		}
+		return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elements)
+	}
+
+	tests := []struct {
+		name          string
+		resource      fwresource.ResourceWithModifyPlan
+		state         map[string]tftypes.Value
+		plan          map[string]tftypes.Value
+		expectedError bool
+	}{
+		{
+			name:     "create",
+			resource: &PoliciesResource{},
+			plan:     protected,
+		},
+		{
+			name:          "destroy",
+			resource:      &PoliciesResource{},
+			state:         protected,
+			expectedError: true,
+		},
+		{
+			name:     "destroy unprotected",
+			resource: &PoliciesResource{},
+			state:    with(protected, "deletion_protection", tftypes.NewValue(tftypes.Bool, false)),
+		},
+		{
+			name:     "update",
+			resource: &PoliciesResource{},
+			state:    protected,
+			plan:     with(protected, "force_delete", tftypes.NewValue(tftypes.Bool, true)),
+		},
+		{
+			name:          "replace by id",
+			resource:      &PoliciesResource{},
+			state:         protected,
+			plan:          with(protected, "id", tftypes.NewValue(tftypes.String, "id-2")),
+			expectedError: true,
+		},
+		{
+			name:          "replace by unprotecting id",
+			resource:      &PoliciesResource{},
+			state:         protected,
+			plan:          with(with(protected, "id", tftypes.NewValue(tftypes.String, "id-2")), "deletion_protection", tftypes.NewValue(tftypes.Bool, false)),
+			expectedError: true,
+		},
+		{
+			name:          "replace by connector",
+			resource:      &AssetsResource{},
+			state:         protected,
+			plan:          with(protected, "connector", tftypes.NewValue(tftypes.String, "consumer")),
+			expectedError: true,
+		},
+		{
+			name:          "replace by asset_ids",
+			resource:      &ContractDefinitionResource{},
+			state:         with(protected, "asset_ids", assetIds("asset-1")),
+			plan:          with(protected, "asset_ids", assetIds("asset-2")),
+			expectedError: true,
+		},
+		{
+			name:          "destroy contract definition",
+			resource:      &ContractDefinitionResource{},
+			state:         protected,
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := testResourceValue(tt.resource, tt.state)
+			plan := testResourceValue(tt.resource, tt.plan)
+			req := fwresource.ModifyPlanRequest{
+				State: tfsdk.State{Schema: state.Schema, Raw: state.Raw},
+				Plan:  tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+			}
+			resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+
+			tt.resource.ModifyPlan(context.Background(), req, resp)
+
+			assert.Equal(t, tt.expectedError, resp.Diagnostics.HasError(), resp.Diagnostics)
+			if tt.expectedError {
+				assert.Equal(t, []string{"deletion_protection"}, getErrorPaths(resp.Diagnostics.Errors()))
+			}
+		})
+	}
+}
+
+// testResourceValue returns a value of the resource holding the given
+// attribute values, or a null value when values is nil.
+func testResourceValue(r fwresource.Resource, values map[string]tftypes.Value) tfsdk.Config {
+	config := newTestResourceConfig(r, values)
+	if values == nil {
+		config.Raw = tftypes.NewValue(config.Raw.Type(), nil)
+	}
+	return config
+}
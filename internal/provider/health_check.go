@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// healthCheckTimeout bounds every request of the health check, as the HTTP
+// client of the connector has no timeout of its own.
+const healthCheckTimeout = 10 * time.Second
+
+// healthStatus is the response of the observability API check endpoints.
+type healthStatus struct {
+	ComponentResults []healthComponentResult `json:"componentResults"`
+	IsSystemHealthy  bool                    `json:"isSystemHealthy"`
+}
+
+type healthComponentResult struct {
+	Component string `json:"component"`
+	IsHealthy bool   `json:"isHealthy"`
+	Failure   *struct {
+		Messages []string `json:"messages"`
+	} `json:"failure"`
+}
+
+// connectorHealth is the outcome of the health check of a connector.
+type connectorHealth struct {
+	Liveness  healthProbe
+	Readiness healthProbe
+	// ManagementAPIVersion is the version of the management API answering
+	// at the management address, or empty when none answered.
+	ManagementAPIVersion string
+	// ManagementError explains why no management API version was detected.
+	ManagementError string
+}
+
+type healthProbe struct {
+	Status *healthStatus
+	// Error is set when the observability API could not be reached or
+	// answered with an unexpected response.
+	Error string
+}
+
+// Healthy reports whether the connector is live, ready and serves a known
+// version of the management API.
+func (h connectorHealth) Healthy() bool {
+	return h.Liveness.healthy() && h.Readiness.healthy() && h.ManagementAPIVersion != ""
+}
+
+func (p healthProbe) healthy() bool {
+	return p.Error == "" && p.Status != nil && p.Status.IsSystemHealthy
+}
+
+func (p healthProbe) String() string {
+	if p.Error != "" {
+		return "unknown (" + p.Error + ")"
+	}
+
+	var failures []string
+	for _, result := range p.Status.ComponentResults {
+		if result.IsHealthy {
+			continue
+		}
+		failure := result.Component
+		if result.Failure != nil && len(result.Failure.Messages) > 0 {
+			failure += ": " + strings.Join(result.Failure.Messages, ", ")
+		}
+		failures = append(failures, failure)
+	}
+
+	if p.Status.IsSystemHealthy {
+		return "healthy"
+	}
+	if len(failures) == 0 {
+		return "unhealthy"
+	}
+	return "unhealthy (" + strings.Join(failures, "; ") + ")"
+}
+
+// Summary describes the health of the connector in a few lines.
+func (h connectorHealth) Summary() string {
+	version := h.ManagementAPIVersion
+	if version == "" {
+		version = "not detected (" + h.ManagementError + ")"
+	}
+
+	return fmt.Sprintf("Liveness: %s\nReadiness: %s\nManagement API version: %s", h.Liveness, h.Readiness, version)
+}
+
+// checkHealth checks the health of every connector and reports the unhealthy
+// ones, with the details of the failed checks.
+func (c *EDCConnectors) checkHealth(ctx context.Context, diags *diag.Diagnostics) {
+	if c.defaultConnector != nil {
+		reportHealth(ctx, "", path.Empty(), c.defaultConnector, diags)
+	}
+
+	names := make([]string, 0, len(c.named))
+	for name := range c.named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		reportHealth(ctx, name, path.Root("connectors").AtMapKey(name), c.named[name], diags)
+	}
+}
+
+func reportHealth(ctx context.Context, name string, root path.Path, managed *managedConnector, diags *diag.Diagnostics) {
+	connector := managed.current
+	health := checkConnectorHealth(ctx, connector)
+
+	if configured := connector.ManagementAPIVersion; configured != "" && health.ManagementAPIVersion != "" && configured != health.ManagementAPIVersion {
+		diags.AddAttributeWarning(
+			root.AtName("management_api_version"),
+			"EDC Management API Version Mismatch",
+			fmt.Sprintf("The management API version is set to %s, but the connector serves the %s API. "+
+				"Set management_api_version to %s or auto.", configured, health.ManagementAPIVersion, health.ManagementAPIVersion),
+		)
+	}
+
+	if err := managed.useDetectedAPIVersion(health.ManagementAPIVersion); err != nil {
+		diags.AddAttributeError(root, "Unable to Create EDC API Client", "EDC Client Error: "+err.Error())
+		return
+	}
+
+	if health.Healthy() {
+		tflog.Info(ctx, "EDC connector is healthy", map[string]interface{}{
+			"connector":              name,
+			"management_api_version": health.ManagementAPIVersion,
+		})
+		return
+	}
+
+	detail := fmt.Sprintf("The connector served at %s did not pass the health check. "+
+		"Check its addresses and token, or unset health_check to plan without reaching the connectors.\n\n%s",
+		*connector.Config.Addresses.Default, health.Summary())
+
+	if name == "" {
+		diags.AddError("EDC Connector Unavailable", detail)
+		return
+	}
+	diags.AddAttributeError(root, "EDC Connector Unavailable", fmt.Sprintf("Connector %q: %s", name, detail))
+}
+
+// checkConnectorHealth queries the observability API served on the default
+// address of the connector and detects the version of its management API.
+func checkConnectorHealth(ctx context.Context, connector *EDCConnector) connectorHealth {
+	health := connectorHealth{
+		Liveness:  probeHealth(ctx, connector, "liveness"),
+		Readiness: probeHealth(ctx, connector, "readiness"),
+	}
+	health.ManagementAPIVersion, health.ManagementError = detectManagementAPIVersion(ctx, connector)
+	return health
+}
+
+func probeHealth(ctx context.Context, connector *EDCConnector, check string) healthProbe {
+	endpoint := strings.TrimSuffix(*connector.Config.Addresses.Default, "/") + "/check/" + check
+
+	res, body, err := doHealthCheckRequest(ctx, connector, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return healthProbe{Error: err.Error()}
+	}
+
+	// The check endpoints answer 503 with the same body when unhealthy.
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusServiceUnavailable {
+		return healthProbe{Error: fmt.Sprintf("GET %s answered %s", endpoint, res.Status)}
+	}
+
+	var status healthStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		return healthProbe{Error: fmt.Sprintf("GET %s answered an unexpected body: %s", endpoint, err)}
+	}
+	return healthProbe{Status: &status}
+}
+
+// managementAPIProbes lists the requests answered successfully by each
+// version of the management API, from the most recent one.
+var managementAPIProbes = []struct {
+	version string
+	method  string
+	path    string
+	body    string
+}{
+	{"v3", http.MethodPost, "/v3/assets/request", `{}`},
+	{"v2", http.MethodPost, "/v2/assets/request", `{}`},
+	{"v1", http.MethodGet, "/assets?limit=1", ""},
+}
+
+// detectManagementAPIVersion returns the version of the management API
+// answering at the management address, or the reason why none was found.
+func detectManagementAPIVersion(ctx context.Context, connector *EDCConnector) (string, string) {
+	base := strings.TrimSuffix(*connector.Config.Addresses.Management, "/")
+
+	for _, probe := range managementAPIProbes {
+		var body io.Reader
+		if probe.body != "" {
+			body = strings.NewReader(probe.body)
+		}
+
+		res, _, err := doHealthCheckRequest(ctx, connector, probe.method, base+probe.path, body)
+		if err != nil {
+			return "", err.Error()
+		}
+
+		switch res.StatusCode {
+		case http.StatusOK:
+			return probe.version, ""
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return "", fmt.Sprintf("the token was rejected by %s", base)
+		}
+	}
+	return "", "no known management API answered at " + base
+}
+
+func doHealthCheckRequest(ctx context.Context, connector *EDCConnector, method, endpoint string, body io.Reader) (*http.Response, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := connector.Config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	content, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, content, nil
+}
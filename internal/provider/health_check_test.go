@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Think-iT-Labs/edc-connector-client-go/edc"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	healthyStatus   = `{"componentResults":[{"component":"BaseRuntime","isHealthy":true,"failure":null}],"isSystemHealthy":true}`
+	unhealthyStatus = `{"componentResults":[{"component":"TransferProcessManager","isHealthy":false,"failure":{"messages":["not started"]}}],"isSystemHealthy":false}`
+)
+
+func Test_checkConnectorHealth(t *testing.T) {
+	tests := []struct {
+		name            string
+		readiness       string
+		readinessStatus int
+		managementPath  string
+		managementCode  int
+		expectedHealthy bool
+		expectedVersion string
+		expectedSummary string
+	}{
+		{
+			name:            "healthy v1 connector",
+			readiness:       healthyStatus,
+			readinessStatus: http.StatusOK,
+			managementPath:  "/management/assets",
+			managementCode:  http.StatusOK,
+			expectedHealthy: true,
+			expectedVersion: "v1",
+			expectedSummary: "Liveness: healthy\nReadiness: healthy\nManagement API version: v1",
+		},
+		{
+			name:            "healthy v2 connector",
+			readiness:       healthyStatus,
+			readinessStatus: http.StatusOK,
+			managementPath:  "/management/v2/assets/request",
+			managementCode:  http.StatusOK,
+			expectedHealthy: true,
+			expectedVersion: "v2",
+			expectedSummary: "Liveness: healthy\nReadiness: healthy\nManagement API version: v2",
+		},
+		{
+			name:            "connector not ready",
+			readiness:       unhealthyStatus,
+			readinessStatus: http.StatusServiceUnavailable,
+			managementPath:  "/management/assets",
+			managementCode:  http.StatusOK,
+			expectedVersion: "v1",
+			expectedSummary: "Liveness: healthy\nReadiness: unhealthy (TransferProcessManager: not started)\nManagement API version: v1",
+		},
+		{
+			name:            "token rejected",
+			readiness:       healthyStatus,
+			readinessStatus: http.StatusOK,
+			managementPath:  "/management/assets",
+			managementCode:  http.StatusUnauthorized,
+			expectedSummary: "Liveness: healthy\nReadiness: healthy\nManagement API version: not detected (the token was rejected by SERVER/management)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/check/liveness", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(healthyStatus))
+			})
+			mux.HandleFunc("/api/check/readiness", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.readinessStatus)
+				_, _ = w.Write([]byte(tt.readiness))
+			})
+			mux.HandleFunc(tt.managementPath, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "1234", r.Header.Get("X-Api-Key"))
+				w.WriteHeader(tt.managementCode)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			connector := newTestConnector(t, server.URL, managementAPIV1)
+			health := checkConnectorHealth(context.Background(), connector)
+
+			assert.Equal(t, tt.expectedHealthy, health.Healthy())
+			assert.Equal(t, tt.expectedVersion, health.ManagementAPIVersion)
+			assert.Equal(t, replaceServerURL(tt.expectedSummary, server.URL), health.Summary())
+		})
+	}
+}
+
+func Test_checkConnectorHealth_unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	health := checkConnectorHealth(context.Background(), newTestConnector(t, server.URL, managementAPIV1))
+
+	assert.False(t, health.Healthy())
+	assert.Contains(t, health.Liveness.String(), "unknown")
+	assert.Contains(t, health.Readiness.String(), "unknown")
+	assert.NotEmpty(t, health.ManagementError)
+}
+
+func newTestConnector(t *testing.T, serverURL, apiVersion string) *EDCConnector {
+	defaultAddress := serverURL + "/api"
+	managementAddress := serverURL + "/management"
+	connector, err := newEDCConnector("1234", edc.Addresses{
+		Default:    &defaultAddress,
+		Management: &managementAddress,
+		Protocol:   &defaultAddress,
+		Public:     &defaultAddress,
+		Control:    &defaultAddress,
+	}, apiVersion)
+	assert.NoError(t, err)
+	return connector
+}
+
+func replaceServerURL(s, serverURL string) string {
+	return strings.ReplaceAll(s, "SERVER", serverURL)
+}
+
+func Test_healthCheckEnabled(t *testing.T) {
+	tests := []struct {
+		name          string
+		healthCheck   types.Bool
+		env           string
+		expected      bool
+		expectedError bool
+	}{
+		{name: "opt-in", healthCheck: types.BoolNull()},
+		{name: "attribute", healthCheck: types.BoolValue(true), env: "false", expected: true},
+		{name: "environment", healthCheck: types.BoolNull(), env: "true", expected: true},
+		{name: "invalid environment", healthCheck: types.BoolNull(), env: "yes please", expectedError: true},
+		{name: "unknown", healthCheck: types.BoolUnknown(), expectedError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("EDC_HEALTH_CHECK", tt.env)
+
+			var diags diag.Diagnostics
+			assert.Equal(t, tt.expected, healthCheckEnabled(EDCProviderModel{HealthCheck: tt.healthCheck}, &diags))
+			assert.Equal(t, tt.expectedError, diags.HasError())
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the JSON types satisfy the framework interfaces.
+var (
+	_ basetypes.StringTypable                    = jsonStringType{}
+	_ xattr.TypeWithValidate                     = jsonStringType{}
+	_ basetypes.StringValuableWithSemanticEquals = jsonStringValue{}
+)
+
+// jsonStringType is a string holding a JSON document. Documents differing
+// only by their formatting or the order of object keys are equal, so that the
+// document read from the connector does not show as drift.
+type jsonStringType struct {
+	basetypes.StringType
+}
+
+func (t jsonStringType) Equal(o attr.Type) bool {
+	other, ok := o.(jsonStringType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t jsonStringType) String() string {
+	return "jsonStringType"
+}
+
+func (t jsonStringType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return jsonStringValue{StringValue: in}, nil
+}
+
+func (t jsonStringType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return jsonStringValue{StringValue: stringValue}, nil
+}
+
+func (t jsonStringType) ValueType(ctx context.Context) attr.Value {
+	return jsonStringValue{}
+}
+
+// Validate reports configured values which are not JSON documents.
+func (t jsonStringType) Validate(ctx context.Context, in tftypes.Value, attributePath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !in.IsKnown() || in.IsNull() {
+		return diags
+	}
+
+	var s string
+	if err := in.As(&s); err != nil {
+		diags.AddAttributeError(attributePath, "Invalid JSON String Value", "Unable to read the value: "+err.Error())
+		return diags
+	}
+
+	var document interface{}
+	if err := json.Unmarshal([]byte(s), &document); err != nil {
+		diags.AddAttributeError(attributePath, "Invalid JSON String Value", "The value must be a JSON document: "+jsonErrorDetail(s, err))
+	}
+	return diags
+}
+
+// jsonErrorDetail returns the message of an error decoding a JSON document,
+// completed by the line and column where decoding failed when known.
+func jsonErrorDetail(document string, err error) string {
+	var offset int64
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxError):
+		offset = syntaxError.Offset
+	case errors.As(err, &typeError):
+		offset = typeError.Offset
+	default:
+		return err.Error()
+	}
+
+	// The offset follows the byte where decoding failed.
+	if offset--; offset < 0 {
+		offset = 0
+	} else if offset > int64(len(document)) {
+		offset = int64(len(document))
+	}
+	line, column := 1, 1
+	for _, r := range document[:offset] {
+		if r == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	return fmt.Sprintf("%s (line %d, column %d)", err.Error(), line, column)
+}
+
+// jsonStringValue is the value of jsonStringType.
+type jsonStringValue struct {
+	basetypes.StringValue
+}
+
+func newJSONStringValue(document interface{}) (jsonStringValue, error) {
+	content, err := json.Marshal(document)
+	if err != nil {
+		return jsonStringValue{}, err
+	}
+	return jsonStringValue{StringValue: basetypes.NewStringValue(string(content))}, nil
+}
+
+func (v jsonStringValue) Type(ctx context.Context) attr.Type {
+	return jsonStringType{}
+}
+
+func (v jsonStringValue) Equal(o attr.Value) bool {
+	other, ok := o.(jsonStringValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals compares the documents held by both values.
+func (v jsonStringValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(jsonStringValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	var document, newDocument interface{}
+	if err := json.Unmarshal([]byte(v.ValueString()), &document); err != nil {
+		return false, diags
+	}
+	if err := json.Unmarshal([]byte(newValue.ValueString()), &newDocument); err != nil {
+		return false, diags
+	}
+	return reflect.DeepEqual(document, newDocument), diags
+}
+
+// Unmarshal decodes the document held by the value into target.
+func (v jsonStringValue) Unmarshal(target interface{}) error {
+	return json.Unmarshal([]byte(v.ValueString()), target)
+}
+
+// jsonObjectValidator validates that a JSON string holds an object. Values
+// which are not JSON documents are left to jsonStringType, which reports them.
+type jsonObjectValidator struct{}
+
+var _ validator.String = jsonObjectValidator{}
+
+func (v jsonObjectValidator) Description(ctx context.Context) string {
+	return "value must be a JSON object"
+}
+
+func (v jsonObjectValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonObjectValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || !json.Valid([]byte(req.ConfigValue.ValueString())) {
+		return
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(req.ConfigValue.ValueString()), &object); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON Object",
+			"The value must be a JSON object: "+jsonErrorDetail(req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+// jsonDecodeValidator validates that a JSON string decodes into the value
+// returned by newTarget, rejecting the object keys the target does not
+// define. Values which are not JSON documents are left to jsonStringType.
+type jsonDecodeValidator struct {
+	description string
+	newTarget   func() interface{}
+}
+
+var _ validator.String = jsonDecodeValidator{}
+
+func (v jsonDecodeValidator) Description(ctx context.Context) string {
+	return "value must be a JSON encoded " + v.description
+}
+
+func (v jsonDecodeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonDecodeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || !json.Valid([]byte(req.ConfigValue.ValueString())) {
+		return
+	}
+
+	document := req.ConfigValue.ValueString()
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v.newTarget()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON Document",
+			fmt.Sprintf("The value must be a JSON encoded %s: %s", v.description, jsonErrorDetail(document, err)),
+		)
+	}
+}
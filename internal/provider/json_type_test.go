@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Think-iT-Labs/edc-connector-client-go/service/policies"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_jsonStringValue_StringSemanticEquals(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		newValue string
+		expected bool
+	}{
+		{name: "formatting", value: `{"a": 1, "b": [true, null]}`, newValue: `{"b":[true,null],"a":1}`, expected: true},
+		{name: "number types", value: `{"a": 1}`, newValue: `{"a": 1.0}`, expected: true},
+		{name: "different values", value: `{"a": 1}`, newValue: `{"a": "1"}`, expected: false},
+		{name: "invalid document", value: `{"a": 1}`, newValue: `{`, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := jsonStringValue{StringValue: basetypes.NewStringValue(tt.value)}
+			newValue := jsonStringValue{StringValue: basetypes.NewStringValue(tt.newValue)}
+
+			equal, diags := value.StringSemanticEquals(context.Background(), newValue)
+			assert.False(t, diags.HasError())
+			assert.Equal(t, tt.expected, equal)
+		})
+	}
+}
+
+func Test_jsonStringType_Validate(t *testing.T) {
+	ctx := context.Background()
+
+	diags := jsonStringType{}.Validate(ctx, tftypes.NewValue(tftypes.String, `{"a": [1, 2]}`), path.Root("properties_json"))
+	assert.False(t, diags.HasError())
+
+	diags = jsonStringType{}.Validate(ctx, tftypes.NewValue(tftypes.String, `{"a": }`), path.Root("properties_json"))
+	assert.True(t, diags.HasError())
+}
+
+func Test_jsonErrorDetail(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		expected string
+	}{
+		{
+			name:     "syntax error",
+			document: "{\n  \"type\": \"Custom\",\n  \"name\": }",
+			expected: "invalid character '}' looking for beginning of value (line 3, column 11)",
+		},
+		{
+			name:     "unexpected end",
+			document: `{"type": `,
+			expected: "unexpected end of JSON input (line 1, column 9)",
+		},
+		{
+			name:     "type error",
+			document: `["Custom"]`,
+			expected: "json: cannot unmarshal array into Go value of type map[string]interface {} (line 1, column 1)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var object map[string]interface{}
+			err := json.Unmarshal([]byte(tt.document), &object)
+			assert.Equal(t, tt.expected, jsonErrorDetail(tt.document, err))
+		})
+	}
+}
+
+func Test_jsonObjectValidator(t *testing.T) {
+	tests := []struct {
+		value         types.String
+		expectedError bool
+	}{
+		{value: types.StringValue(`{"a": 1}`)},
+		{value: types.StringValue(`[1]`), expectedError: true},
+		{value: types.StringNull()},
+		{value: types.StringUnknown()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value.String(), func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			jsonObjectValidator{}.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("properties_json"),
+				ConfigValue: tt.value,
+			}, resp)
+			assert.Equal(t, tt.expectedError, resp.Diagnostics.HasError())
+		})
+	}
+}
+
+func Test_jsonDecodeValidator(t *testing.T) {
+	tests := []struct {
+		value         types.String
+		expectedError bool
+	}{
+		{value: types.StringValue(`{"a": 1}`)},
+		{value: types.StringValue(`{"a": 1, "b": 2}`), expectedError: true},
+		{value: types.StringValue(`{"a": "1"}`), expectedError: true},
+		{value: types.StringNull()},
+		{value: types.StringUnknown()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value.String(), func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			jsonDecodeValidator{
+				description: "document",
+				newTarget:   func() interface{} { return &struct{ A int }{} },
+			}.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("consequence"),
+				ConfigValue: tt.value,
+			}, resp)
+			assert.Equal(t, tt.expectedError, resp.Diagnostics.HasError())
+		})
+	}
+}
+
+func Test_jsonStringAttributes_singleDiagnostic(t *testing.T) {
+	ctx := context.Background()
+	attributes := map[string]schema.StringAttribute{
+		"properties_json": PropertiesJSONSchema(),
+		"consequence":     PolicyNodeJSONSchema("duty", "`consequence`", func() interface{} { return &policies.Duty{} }),
+	}
+	for name, attribute := range attributes {
+		for _, document := range []string{`{"a": `, `[1]`} {
+			t.Run(name+" "+document, func(t *testing.T) {
+				diags := jsonStringType{}.Validate(ctx, tftypes.NewValue(tftypes.String, document), path.Root(name))
+				for _, v := range attribute.Validators {
+					resp := &validator.StringResponse{}
+					v.ValidateString(ctx, validator.StringRequest{
+						Path:        path.Root(name),
+						ConfigValue: types.StringValue(document),
+					}, resp)
+					diags.Append(resp.Diagnostics...)
+				}
+				assert.Len(t, diags.Errors(), 1, diags)
+			})
+		}
+	}
+}
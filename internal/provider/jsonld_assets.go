@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Think-iT-Labs/edc-connector-client-go/service/assets"
+)
+
+// jsonldAssets implements assetsAPI on top of the JSON-LD management API.
+type jsonldAssets struct {
+	client *managementClient
+}
+
+var _ assetsAPI = &jsonldAssets{}
+
+// jsonldAsset is the compacted representation of an asset. The v2 API nests
+// it in an "asset" object next to the data address, the v3 API holds the
+// data address in the asset.
+type jsonldAsset struct {
+	Id                string                 `json:"@id"`
+	CreatedAt         int64                  `json:"createdAt"`
+	Properties        map[string]interface{} `json:"properties"`
+	PrivateProperties map[string]interface{} `json:"privateProperties,omitempty"`
+	DataAddress       map[string]interface{} `json:"dataAddress,omitempty"`
+}
+
+func (a *jsonldAssets) CreateAsset(createAssetInput assetInput) (*assets.CreateAssetOutput, error) {
+	dataAddress := map[string]interface{}{"@type": "DataAddress"}
+	for key, value := range createAssetInput.DataAddress {
+		dataAddress[toJSONLDDataAddressKey(key)] = value
+	}
+
+	asset := map[string]interface{}{
+		"@type": "Asset",
+	}
+
+	properties := make(map[string]interface{}, len(createAssetInput.Properties))
+	for key, value := range createAssetInput.Properties {
+		key = fromLegacyAssetPropertyKey(key)
+		properties[key] = value
+
+		// Resources send the IRI of the keys, expanded against their context.
+		if compactIRI(key) == "id" {
+			asset["@id"] = value
+		}
+	}
+	asset["properties"] = properties
+
+	if len(createAssetInput.PrivateProperties) != 0 {
+		privateProperties := make(map[string]interface{}, len(createAssetInput.PrivateProperties))
+		for key, value := range createAssetInput.PrivateProperties {
+			privateProperties[fromLegacyAssetPropertyKey(key)] = value
+		}
+		asset["privateProperties"] = privateProperties
+	}
+
+	payload := map[string]interface{}{"@context": jsonldContext}
+	if a.client.version == managementAPIV2 {
+		payload["asset"] = asset
+		payload["dataAddress"] = dataAddress
+	} else {
+		for key, value := range asset {
+			payload[key] = value
+		}
+		payload["dataAddress"] = dataAddress
+	}
+
+	var response idResponse
+	if err := a.client.do(http.MethodPost, "/assets", payload, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &assets.CreateAssetOutput{
+		Id:        response.Id,
+		CreatedAt: response.CreatedAt,
+	}, nil
+}
+
+func (a *jsonldAssets) getAsset(assetId string) (*jsonldAsset, error) {
+	var asset jsonldAsset
+	if err := a.client.do(http.MethodGet, "/assets/"+url.PathEscape(assetId), nil, &asset, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+func (a *jsonldAssets) GetAsset(assetId string) (*assetOutput, error) {
+	asset, err := a.getAsset(assetId)
+	if err != nil {
+		return nil, err
+	}
+	return asset.output(), nil
+}
+
+func (a *jsonldAssets) ListAssets() ([]assetOutput, error) {
+	var outputs []assetOutput
+	for offset := 0; ; offset += queryPageSize {
+		var page []jsonldAsset
+		if err := a.client.do(http.MethodPost, "/assets/request", a.client.querySpec(offset), &page, http.StatusOK); err != nil {
+			return nil, err
+		}
+		for _, asset := range page {
+			outputs = append(outputs, *asset.output())
+		}
+		if len(page) < queryPageSize {
+			return outputs, nil
+		}
+	}
+}
+
+// output returns the asset keyed by the v1 property keys.
+func (asset *jsonldAsset) output() *assetOutput {
+	properties := make(map[string]interface{}, len(asset.Properties)+1)
+	for key, value := range asset.Properties {
+		properties[toLegacyAssetPropertyKey(key)] = value
+	}
+	properties[legacyAssetPropertyPrefix+"id"] = asset.Id
+
+	var privateProperties map[string]interface{}
+	if len(asset.PrivateProperties) != 0 {
+		privateProperties = make(map[string]interface{}, len(asset.PrivateProperties))
+		for key, value := range asset.PrivateProperties {
+			privateProperties[toLegacyAssetPropertyKey(key)] = value
+		}
+	}
+
+	return &assetOutput{
+		Id:                asset.Id,
+		CreatedAt:         asset.CreatedAt,
+		Properties:        properties,
+		PrivateProperties: privateProperties,
+	}
+}
+
+func (a *jsonldAssets) GetAssetDataAddress(assetId string) (*assets.AssetDataAddressOutput, error) {
+	dataAddress := map[string]interface{}{}
+
+	if a.client.version == managementAPIV2 {
+		err := a.client.do(http.MethodGet, "/assets/"+url.PathEscape(assetId)+"/dataaddress", nil, &dataAddress, http.StatusOK)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		asset, err := a.getAsset(assetId)
+		if err != nil {
+			return nil, err
+		}
+		dataAddress = asset.DataAddress
+	}
+
+	properties := make(map[string]string, len(dataAddress))
+	for key, value := range dataAddress {
+		if key == "@type" || key == "@id" {
+			continue
+		}
+		properties[key] = stringValue(value)
+	}
+
+	return &assets.AssetDataAddressOutput{
+		AssetProperties: properties,
+	}, nil
+}
+
+// toJSONLDDataAddressKey expands the data address keys holding a colon, such
+// as "header:Accept" or "oauth2:tokenUrl", to the EDC namespace, as JSON-LD
+// takes them for IRIs of an unknown scheme otherwise. They are compacted back
+// with the responses.
+func toJSONLDDataAddressKey(key string) string {
+	prefix, local, found := strings.Cut(key, ":")
+	if !found || strings.HasPrefix(key, "@") || strings.HasPrefix(local, "//") {
+		return key
+	}
+	if _, ok := jsonldContext[prefix]; ok {
+		return key
+	}
+	return edcNamespace + key
+}
+
+func (a *jsonldAssets) DeleteAsset(assetId string) error {
+	return a.client.do(http.MethodDelete, "/assets/"+url.PathEscape(assetId), nil, nil, http.StatusNoContent)
+}
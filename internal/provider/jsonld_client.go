@@ -0,0 +1,285 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Think-iT-Labs/edc-connector-client-go/edc"
+	edchttp "github.com/Think-iT-Labs/edc-connector-client-go/edc/transport/http"
+)
+
+// JSON-LD namespaces of the terms exchanged with the management API.
+const (
+	edcNamespace  = "https://w3id.org/edc/v0.0.1/ns/"
+	odrlNamespace = "http://www.w3.org/ns/odrl/2/"
+)
+
+// legacyAssetPropertyPrefix prefixes the asset properties of the v1 API,
+// which became terms of the EDC namespace in the JSON-LD APIs.
+const legacyAssetPropertyPrefix = "asset:prop:"
+
+// jsonldContext is sent with every request. Unprefixed terms belong to the
+// EDC namespace.
+var jsonldContext = map[string]interface{}{
+	"@vocab": edcNamespace,
+	"edc":    edcNamespace,
+	"odrl":   odrlNamespace,
+}
+
+// managementClient calls the management API without the EDC client. With the
+// JSON-LD based API, from its version 2, the payloads are translated from and
+// to the v1 representation used by the resources, so that their schemas do not
+// depend on the API version. With the v1 API, it calls the endpoints where the
+// EDC client falls short, without translation.
+type managementClient struct {
+	httpClient *edchttp.HTTPClient
+	baseURL    string
+	version    string
+}
+
+func newJSONLDClient(cfg *edc.Config, version string) *managementClient {
+	return &managementClient{
+		httpClient: cfg.HTTPClient,
+		baseURL:    strings.TrimSuffix(*cfg.Addresses.Management, "/") + "/" + version,
+		version:    version,
+	}
+}
+
+// newLegacyClient returns a client of the v1 API, served at the management
+// address itself.
+func newLegacyClient(cfg *edc.Config) *managementClient {
+	return &managementClient{
+		httpClient: cfg.HTTPClient,
+		baseURL:    strings.TrimSuffix(*cfg.Addresses.Management, "/"),
+		version:    managementAPIV1,
+	}
+}
+
+// querySpec returns the payload requesting the page of a query starting at
+// offset.
+func (c *managementClient) querySpec(offset int) map[string]interface{} {
+	payload := map[string]interface{}{
+		"offset": offset,
+		"limit":  queryPageSize,
+	}
+	if c.version != managementAPIV1 {
+		payload["@context"] = jsonldContext
+		payload["@type"] = "QuerySpec"
+	}
+	return payload
+}
+
+// do sends the payload to the endpoint, relative to the versioned management
+// address, and decodes the compacted response into response.
+func (c *managementClient) do(method, endpoint string, payload interface{}, response interface{}, expectedStatusCode int) error {
+	var body io.Reader
+	if payload != nil {
+		content, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("unable to encode the request: %w", err)
+		}
+		body = bytes.NewReader(content)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+endpoint, body)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	content, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != expectedStatusCode {
+		var violations apiErrorResponse
+		if json.Unmarshal(content, &violations) == nil && len(violations) != 0 {
+			for i := range violations {
+				if c.version != managementAPIV1 {
+					violations[i].Path = compactIRI(violations[i].Path)
+				}
+			}
+			return violations
+		}
+		return fmt.Errorf("%s %s answered %s: %s", method, c.baseURL+endpoint, res.Status, strings.TrimSpace(string(content)))
+	}
+
+	if response == nil {
+		return nil
+	}
+
+	if c.version == managementAPIV1 {
+		if err := json.Unmarshal(content, response); err != nil {
+			return fmt.Errorf("unable to decode the response: %w", err)
+		}
+		return nil
+	}
+
+	var expanded interface{}
+	if err := json.Unmarshal(content, &expanded); err != nil {
+		return fmt.Errorf("unable to decode the response: %w", err)
+	}
+
+	compacted, err := json.Marshal(compactJSONLD(expandResponsePrefixes(expanded)))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(compacted, response)
+}
+
+// idResponse is the response to the creation of any entity.
+type idResponse struct {
+	Id        string `json:"@id"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+// compactJSONLD strips the EDC and ODRL namespaces from the terms of a JSON-LD
+// document, unwraps value objects and drops its context.
+func compactJSONLD(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		if literal, ok := typed["@value"]; ok {
+			return literal
+		}
+
+		compacted := make(map[string]interface{}, len(typed))
+		for key, element := range typed {
+			if key == "@context" {
+				continue
+			}
+			if key == "@type" {
+				compacted[key] = compactTypes(element)
+				continue
+			}
+			compacted[compactIRI(key)] = compactJSONLD(element)
+		}
+		return compacted
+	case []interface{}:
+		compacted := make([]interface{}, len(typed))
+		for i, element := range typed {
+			compacted[i] = compactJSONLD(element)
+		}
+		return compacted
+	default:
+		return value
+	}
+}
+
+// expandResponsePrefixes expands the keys using the prefixes defined by the
+// context of a response, such as "dct:type", which compactJSONLD would not
+// recognize otherwise. The elements of a list response each have their own
+// context.
+func expandResponsePrefixes(value interface{}) interface{} {
+	if list, ok := value.([]interface{}); ok {
+		expanded := make([]interface{}, len(list))
+		for i, element := range list {
+			expanded[i] = expandResponsePrefixes(element)
+		}
+		return expanded
+	}
+
+	document, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	context, ok := document["@context"].(map[string]interface{})
+	if !ok {
+		return value
+	}
+
+	prefixes := jsonldPrefixes{}
+	for prefix, namespace := range context {
+		if namespace, ok := namespace.(string); ok && !strings.HasPrefix(prefix, "@") {
+			prefixes[prefix] = namespace
+		}
+	}
+
+	return renameTerms(value, func(key string) string {
+		prefix, local, found := strings.Cut(key, ":")
+		if !found || strings.HasPrefix(local, "//") {
+			return key
+		}
+		if namespace, ok := prefixes[prefix]; ok {
+			return namespace + local
+		}
+		return key
+	})
+}
+
+func compactTypes(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case string:
+		return compactIRI(typed)
+	case []interface{}:
+		if len(typed) == 1 {
+			return compactTypes(typed[0])
+		}
+		return typed
+	default:
+		return value
+	}
+}
+
+// compactIRI strips the EDC and ODRL namespaces, expanded or prefixed, from
+// the IRIs found in s.
+func compactIRI(s string) string {
+	for _, prefix := range []string{edcNamespace, odrlNamespace} {
+		s = strings.ReplaceAll(s, prefix, "")
+	}
+	for _, prefix := range []string{"edc:", "odrl:"} {
+		if strings.HasPrefix(s, prefix) {
+			s = s[len(prefix):]
+		}
+		s = strings.ReplaceAll(s, "."+prefix, ".")
+	}
+	return s
+}
+
+// asList returns the elements of a JSON-LD value, which is compacted to a
+// single element when it holds only one.
+func asList(value interface{}) []interface{} {
+	switch typed := value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return typed
+	default:
+		return []interface{}{typed}
+	}
+}
+
+// toLegacyAssetPropertyKey returns the v1 key of a compacted asset property.
+// Properties of other namespaces keep their IRI.
+func toLegacyAssetPropertyKey(key string) string {
+	if strings.Contains(key, ":") {
+		return key
+	}
+	return legacyAssetPropertyPrefix + key
+}
+
+// fromLegacyAssetPropertyKey returns the JSON-LD term of a v1 asset property.
+func fromLegacyAssetPropertyKey(key string) string {
+	return strings.TrimPrefix(key, legacyAssetPropertyPrefix)
+}
+
+// stringValue returns strings as is, and the JSON encoding of other values.
+func stringValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	content, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(content)
+}
@@ -0,0 +1,516 @@
+package provider
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Think-iT-Labs/edc-connector-client-go/service/assets"
+	"github.com/Think-iT-Labs/edc-connector-client-go/service/contractdefinition"
+	"github.com/Think-iT-Labs/edc-connector-client-go/service/policies"
+	"github.com/stretchr/testify/assert"
+)
+
+// newJSONLDTestServer answers every request with the given status and body,
+// and records the path and decoded payload of the last request.
+func newJSONLDTestServer(t *testing.T, status int, body string) (*httptest.Server, *string, *map[string]interface{}) {
+	var requestPath string
+	payload := map[string]interface{}{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.Method + " " + r.URL.Path
+		content, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		if len(content) != 0 {
+			payload = map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal(content, &payload))
+		}
+
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requestPath, &payload
+}
+
+func Test_assetsAPI_CreateAsset(t *testing.T) {
+	baseURL := "https://example.com"
+	input := assetInput{
+		Properties: map[string]interface{}{
+			"asset:prop:id":   "asset-1",
+			"asset:prop:name": "Asset",
+			"keywords":        []interface{}{"a", "b"},
+		},
+		DataAddress: map[string]interface{}{
+			"type":    "HttpData",
+			"baseUrl": baseURL,
+		},
+	}
+	httpDataAddress := map[string]interface{}{
+		"type":    "HttpData",
+		"baseUrl": baseURL,
+	}
+	jsonldDataAddress := map[string]interface{}{
+		"@type":   "DataAddress",
+		"type":    "HttpData",
+		"baseUrl": baseURL,
+	}
+	jsonldProperties := map[string]interface{}{"id": "asset-1", "name": "Asset", "keywords": []interface{}{"a", "b"}}
+
+	tests := []struct {
+		name                string
+		version             string
+		path                string
+		expectedProperties  map[string]interface{}
+		expectedDataAddress map[string]interface{}
+		properties          func(map[string]interface{}) interface{}
+		dataAddress         func(map[string]interface{}) interface{}
+	}{
+		{
+			name:                "v1 keeps the keys",
+			version:             managementAPIV1,
+			path:                "POST /management/assets",
+			expectedProperties:  input.Properties,
+			expectedDataAddress: httpDataAddress,
+			properties: func(p map[string]interface{}) interface{} {
+				return p["asset"].(map[string]interface{})["properties"]
+			},
+			dataAddress: func(p map[string]interface{}) interface{} {
+				return p["dataAddress"].(map[string]interface{})["properties"]
+			},
+		},
+		{
+			name:                "v2 nests the asset",
+			version:             managementAPIV2,
+			path:                "POST /management/v2/assets",
+			expectedProperties:  jsonldProperties,
+			expectedDataAddress: jsonldDataAddress,
+			properties: func(p map[string]interface{}) interface{} {
+				return p["asset"].(map[string]interface{})["properties"]
+			},
+			dataAddress: func(p map[string]interface{}) interface{} { return p["dataAddress"] },
+		},
+		{
+			name:                "v3 holds the data address in the asset",
+			version:             managementAPIV3,
+			path:                "POST /management/v3/assets",
+			expectedProperties:  jsonldProperties,
+			expectedDataAddress: jsonldDataAddress,
+			properties:          func(p map[string]interface{}) interface{} { return p["properties"] },
+			dataAddress:         func(p map[string]interface{}) interface{} { return p["dataAddress"] },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requestPath, payload := newJSONLDTestServer(t, http.StatusOK, `{"@id": "asset-1", "id": "asset-1", "edc:createdAt": 1234, "createdAt": 1234}`)
+
+			output, err := newTestConnector(t, server.URL, tt.version).Assets.CreateAsset(input)
+			assert.NoError(t, err)
+			assert.Equal(t, &assets.CreateAssetOutput{Id: "asset-1", CreatedAt: 1234}, output)
+
+			assert.Equal(t, tt.path, *requestPath)
+			assert.Equal(t, tt.expectedProperties, tt.properties(*payload))
+			assert.Equal(t, tt.expectedDataAddress, tt.dataAddress(*payload))
+		})
+	}
+}
+
+func Test_assetsAPI_CreateAsset_privateProperties(t *testing.T) {
+	input := assetInput{
+		Properties:        map[string]interface{}{"asset:prop:id": "asset-1"},
+		PrivateProperties: map[string]interface{}{"asset:prop:billing": "internal"},
+		DataAddress:       map[string]interface{}{"type": "Custom"},
+	}
+
+	server, _, payload := newJSONLDTestServer(t, http.StatusOK, `{"@id": "asset-1"}`)
+	_, err := newTestConnector(t, server.URL, managementAPIV3).Assets.CreateAsset(input)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"billing": "internal"}, (*payload)["privateProperties"])
+
+	server, requestPath, _ := newJSONLDTestServer(t, http.StatusOK, `{"id": "asset-1"}`)
+	_, err = newTestConnector(t, server.URL, managementAPIV1).Assets.CreateAsset(input)
+	assert.ErrorContains(t, err, "private properties")
+	assert.Empty(t, *requestPath)
+}
+
+func Test_legacyAssets_GetAsset(t *testing.T) {
+	server, requestPath, _ := newJSONLDTestServer(t, http.StatusOK, `{
+		"id": "asset-1",
+		"createdAt": 1234,
+		"properties": {"asset:prop:id": "asset-1", "edc:version": 2, "keywords": ["a"]}
+	}`)
+
+	asset, err := newTestConnector(t, server.URL, managementAPIV1).Assets.GetAsset("asset-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "GET /management/assets/asset-1", *requestPath)
+	assert.Equal(t, &assetOutput{
+		Id:        "asset-1",
+		CreatedAt: 1234,
+		Properties: map[string]interface{}{
+			"asset:prop:id": "asset-1",
+			"edc:version":   2.0,
+			"keywords":      []interface{}{"a"},
+		},
+	}, asset)
+}
+
+func Test_jsonldAssets_GetAsset(t *testing.T) {
+	server, requestPath, _ := newJSONLDTestServer(t, http.StatusOK, `{
+		"@id": "asset-1",
+		"@type": "edc:Asset",
+		"edc:properties": {
+			"edc:name": "Asset",
+			"edc:version": {"@value": "1.0"},
+			"http://purl.org/dc/terms/type": "dataset",
+			"dct:format": "csv"
+		},
+		"edc:privateProperties": {"edc:billing": "internal"},
+		"edc:dataAddress": {"@type": "edc:DataAddress", "edc:type": "HttpData", "edc:baseUrl": "https://example.com"},
+		"@context": {"edc": "https://w3id.org/edc/v0.0.1/ns/", "dct": "http://purl.org/dc/terms/"}
+	}`)
+	connector := newTestConnector(t, server.URL, managementAPIV3)
+
+	asset, err := connector.Assets.GetAsset("asset-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "GET /management/v3/assets/asset-1", *requestPath)
+	assert.Equal(t, map[string]interface{}{
+		"asset:prop:id":                   "asset-1",
+		"asset:prop:name":                 "Asset",
+		"asset:prop:version":              "1.0",
+		"http://purl.org/dc/terms/type":   "dataset",
+		"http://purl.org/dc/terms/format": "csv",
+	}, asset.Properties)
+	assert.Equal(t, map[string]interface{}{"asset:prop:billing": "internal"}, asset.PrivateProperties)
+
+	dataAddress, err := connector.Assets.GetAssetDataAddress("asset-1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"type": "HttpData", "baseUrl": "https://example.com"}, dataAddress.AssetProperties)
+}
+
+func Test_assetsAPI_ListAssets(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		body     string
+		path     string
+		expected []assetOutput
+	}{
+		{
+			name:    "v1",
+			version: managementAPIV1,
+			body:    `[{"id": "asset-1", "createdAt": 1234, "properties": {"asset:prop:id": "asset-1", "asset:prop:name": "Asset"}}]`,
+			path:    "POST /management/assets/request",
+			expected: []assetOutput{{
+				Id:         "asset-1",
+				CreatedAt:  1234,
+				Properties: map[string]interface{}{"asset:prop:id": "asset-1", "asset:prop:name": "Asset"},
+			}},
+		},
+		{
+			name:    "v3",
+			version: managementAPIV3,
+			body: `[{
+				"@id": "asset-1",
+				"createdAt": 1234,
+				"properties": {"name": "Asset", "dct:format": "csv"},
+				"@context": {"@vocab": "https://w3id.org/edc/v0.0.1/ns/", "dct": "http://purl.org/dc/terms/"}
+			}]`,
+			path: "POST /management/v3/assets/request",
+			expected: []assetOutput{{
+				Id:        "asset-1",
+				CreatedAt: 1234,
+				Properties: map[string]interface{}{
+					"asset:prop:id":                   "asset-1",
+					"asset:prop:name":                 "Asset",
+					"http://purl.org/dc/terms/format": "csv",
+				},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requestPath, payload := newJSONLDTestServer(t, http.StatusOK, tt.body)
+
+			outputs, err := newTestConnector(t, server.URL, tt.version).Assets.ListAssets()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.path, *requestPath)
+			assert.Equal(t, 0.0, (*payload)["offset"])
+			assert.Equal(t, float64(queryPageSize), (*payload)["limit"])
+			assert.Equal(t, tt.expected, outputs)
+		})
+	}
+}
+
+func Test_legacyQueries(t *testing.T) {
+	server, requestPath, payload := newJSONLDTestServer(t, http.StatusOK, `[
+		{"id": "cd-1", "accessPolicyId": "access", "contractPolicyId": "contract", "criteria": [
+			{"operandLeft": "asset:prop:id", "operator": "in", "operandRight": ["asset-1"]}
+		]}
+	]`)
+	connector := newTestConnector(t, server.URL, managementAPIV1)
+
+	definitions, err := connector.ContractDefinitions.ListContractDefinitions()
+	assert.NoError(t, err)
+	assert.Equal(t, "POST /management/contractdefinitions/request", *requestPath)
+	assert.Equal(t, map[string]interface{}{"offset": 0.0, "limit": float64(queryPageSize)}, *payload)
+	assert.Equal(t, []contractDefinition{{
+		Id:               "cd-1",
+		AccessPolicyId:   "access",
+		ContractPolicyId: "contract",
+		Criteria:         []criterion{{OperandLeft: "asset:prop:id", Operator: "in", OperandRight: []string{"asset-1"}}},
+	}}, definitions)
+
+	server, requestPath, _ = newJSONLDTestServer(t, http.StatusOK, `[{"id": "agreement-1", "assetId": "asset-1", "policy": {}}]`)
+	agreements, err := newTestConnector(t, server.URL, managementAPIV1).ContractAgreements.ListContractAgreements()
+	assert.NoError(t, err)
+	assert.Equal(t, "POST /management/contractagreements/request", *requestPath)
+	assert.Equal(t, []contractAgreement{{Id: "agreement-1", AssetId: "asset-1"}}, agreements)
+}
+
+func Test_legacyPolicies(t *testing.T) {
+	server, requestPath, payload := newJSONLDTestServer(t, http.StatusOK, `{"id": "policy-1", "createdAt": 1234}`)
+	connector := newTestConnector(t, server.URL, managementAPIV1)
+
+	id := "policy-1"
+	compensateAction := "COMPENSATE"
+	input := policyInput{Id: &id}
+	input.Policy.Prohibitions = &[]legacyProhibition{
+		{Remedies: &[]policies.Duty{{Action: &policies.Action{ActionType: &compensateAction}}}},
+	}
+	output, err := connector.Policies.CreatePolicy(input)
+	assert.NoError(t, err)
+	assert.Equal(t, "POST /management/policydefinitions", *requestPath)
+	assert.Equal(t, &policies.CreatePolicyOutput{Id: "policy-1", CreatedAt: 1234}, output)
+	assert.Equal(t, map[string]interface{}{
+		"id": "policy-1",
+		"policy": map[string]interface{}{
+			"prohibitions": []interface{}{
+				map[string]interface{}{"remedies": []interface{}{map[string]interface{}{"action": map[string]interface{}{"type": "COMPENSATE"}}}},
+			},
+		},
+	}, *payload)
+
+	server, requestPath, _ = newJSONLDTestServer(t, http.StatusOK, `{"id": "policy-1", "createdAt": 1234, "policy": {
+		"prohibitions": [{"action": {"type": "DISTRIBUTE"}, "remedies": [{"action": {"type": "COMPENSATE"}}]}]
+	}}`)
+	definition, err := newTestConnector(t, server.URL, managementAPIV1).Policies.GetPolicy("policy-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "GET /management/policydefinitions/policy-1", *requestPath)
+	assert.Equal(t, "policy-1", definition.Id)
+	assert.Equal(t, int64(1234), definition.CreatedAt)
+	assert.Equal(t, "DISTRIBUTE", *(*definition.Policy.Prohibitions)[0].Action.ActionType)
+	assert.Equal(t, "COMPENSATE", *(*(*definition.Policy.Prohibitions)[0].Remedies)[0].Action.ActionType)
+}
+
+func Test_jsonldPolicies_roundTrip(t *testing.T) {
+	useAction := "USE"
+	target := "asset-1"
+	distributeAction := "DISTRIBUTE"
+	compensateAction := "COMPENSATE"
+	policy := legacyPolicy{
+		Policy: policies.Policy{
+			Type: map[string]policies.PolicyType{legacyPolicyTypeKey: policies.OfferPolicyType},
+			Permissions: &[]policies.Permission{
+				{
+					Target:      &target,
+					Action:      &policies.Action{ActionType: &useAction},
+					Constraints: &[]policies.Constraint{{EdcType: "AtomicConstraint"}},
+				},
+			},
+		},
+		Prohibitions: &[]legacyProhibition{
+			{
+				Prohibition: policies.Prohibition{Action: &policies.Action{ActionType: &distributeAction}},
+				Remedies:    &[]policies.Duty{{Action: &policies.Action{ActionType: &compensateAction}}},
+			},
+		},
+	}
+
+	odrl, err := toODRLPolicy(policy)
+	assert.NoError(t, err)
+	assert.Equal(t, "odrl:Offer", odrl["@type"])
+	assert.Contains(t, odrl, "odrl:permission")
+	assert.Contains(t, odrl["odrl:prohibition"].([]interface{})[0], "odrl:remedy")
+
+	// The connector compacts lists holding a single element and may return
+	// strings as node references.
+	compacted := compactJSONLD(odrl).(map[string]interface{})
+	permission := compacted["permission"].([]interface{})[0].(map[string]interface{})
+	permission["target"] = map[string]interface{}{"@id": target}
+	compacted["permission"] = permission
+
+	translated, err := fromODRLPolicy(compacted)
+	assert.NoError(t, err)
+	assert.Equal(t, policy.Type, translated.Type)
+	assert.Equal(t, policy.Permissions, translated.Permissions)
+	assert.Equal(t, policy.Prohibitions, translated.Prohibitions)
+}
+
+func Test_jsonldContractDefinitions(t *testing.T) {
+	server, requestPath, payload := newJSONLDTestServer(t, http.StatusOK, `{"@id": "cd-1"}`)
+	connector := newTestConnector(t, server.URL, managementAPIV2)
+
+	_, err := connector.ContractDefinitions.CreateContractDefinition(contractDefinition{
+		Id:               "cd-1",
+		AccessPolicyId:   "access",
+		ContractPolicyId: "contract",
+		Criteria: []criterion{
+			{OperandLeft: "asset:prop:id", Operator: "=", OperandRight: "asset-1"},
+			{OperandLeft: "asset:prop:id", Operator: "in", OperandRight: []string{"asset-1", "asset-2"}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "POST /management/v2/contractdefinitions", *requestPath)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"@type":        "Criterion",
+			"operandLeft":  edcNamespace + "id",
+			"operator":     "=",
+			"operandRight": "asset-1",
+		},
+		map[string]interface{}{
+			"@type":        "Criterion",
+			"operandLeft":  edcNamespace + "id",
+			"operator":     "in",
+			"operandRight": []interface{}{"asset-1", "asset-2"},
+		},
+	}, (*payload)["assetsSelector"])
+
+	server, _, _ = newJSONLDTestServer(t, http.StatusOK, `{
+		"@id": "cd-1",
+		"edc:accessPolicyId": "access",
+		"edc:contractPolicyId": "contract",
+		"edc:assetsSelector": [
+			{
+				"@type": "edc:Criterion",
+				"edc:operandLeft": "https://w3id.org/edc/v0.0.1/ns/id",
+				"edc:operator": "=",
+				"edc:operandRight": "asset-1"
+			},
+			{
+				"@type": "edc:Criterion",
+				"edc:operandLeft": "https://w3id.org/edc/v0.0.1/ns/id",
+				"edc:operator": "in",
+				"edc:operandRight": ["asset-1", "asset-2"]
+			}
+		]
+	}`)
+	output, err := newTestConnector(t, server.URL, managementAPIV2).ContractDefinitions.GetContractDefinition("cd-1")
+	assert.NoError(t, err)
+	assert.Equal(t, &contractDefinition{
+		Id:               "cd-1",
+		AccessPolicyId:   "access",
+		ContractPolicyId: "contract",
+		Criteria: []criterion{
+			{OperandLeft: "asset:prop:id", Operator: "=", OperandRight: "asset-1"},
+			{OperandLeft: "asset:prop:id", Operator: "in", OperandRight: []string{"asset-1", "asset-2"}},
+		},
+	}, output)
+}
+
+func Test_legacyContractDefinitions(t *testing.T) {
+	server, requestPath, payload := newJSONLDTestServer(t, http.StatusOK, `{"id": "cd-1", "createdAt": 1234}`)
+
+	output, err := newTestConnector(t, server.URL, managementAPIV1).ContractDefinitions.CreateContractDefinition(contractDefinition{
+		AccessPolicyId:   "access",
+		ContractPolicyId: "contract",
+		Validity:         3600,
+		Criteria: []criterion{
+			{OperandLeft: "asset:prop:id", Operator: "in", OperandRight: []string{"asset-1", "asset-2"}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &contractdefinition.CreateContractDefinitionOutput{Id: "cd-1", CreatedAt: 1234}, output)
+	assert.Equal(t, "POST /management/contractdefinitions", *requestPath)
+	assert.Equal(t, map[string]interface{}{
+		"accessPolicyId":   "access",
+		"contractPolicyId": "contract",
+		"validity":         3600.0,
+		"criteria": []interface{}{map[string]interface{}{
+			"operandLeft":  "asset:prop:id",
+			"operator":     "in",
+			"operandRight": []interface{}{"asset-1", "asset-2"},
+		}},
+	}, *payload)
+
+	server, requestPath, _ = newJSONLDTestServer(t, http.StatusOK, `{
+		"id": "cd-1",
+		"accessPolicyId": "access",
+		"contractPolicyId": "contract",
+		"validity": 3600,
+		"createdAt": 1234,
+		"criteria": [{"operandLeft": "asset:prop:id", "operator": "in", "operandRight": ["asset-1"]}]
+	}`)
+	cd, err := newTestConnector(t, server.URL, managementAPIV1).ContractDefinitions.GetContractDefinition("cd-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "GET /management/contractdefinitions/cd-1", *requestPath)
+	assert.Equal(t, &contractDefinition{
+		Id:               "cd-1",
+		AccessPolicyId:   "access",
+		ContractPolicyId: "contract",
+		Validity:         3600,
+		CreatedAt:        1234,
+		Criteria: []criterion{
+			{OperandLeft: "asset:prop:id", Operator: "in", OperandRight: []string{"asset-1"}},
+		},
+	}, cd)
+}
+
+func Test_managementClient_errors(t *testing.T) {
+	server, _, _ := newJSONLDTestServer(t, http.StatusBadRequest,
+		`[{"message": "must not be blank", "type": "ValidationFailure", "path": "https://w3id.org/edc/v0.0.1/ns/accessPolicyId"}]`)
+
+	err := newTestConnector(t, server.URL, managementAPIV3).Assets.DeleteAsset("asset-1")
+	assert.Equal(t, apiErrorResponse{{Message: "must not be blank", Type: "ValidationFailure", Path: "accessPolicyId"}}, err)
+	assert.Equal(t, []apiViolation{{Message: "must not be blank", Type: "ValidationFailure", Path: "accessPolicyId", InvalidValue: json.RawMessage("null")}}, apiViolations(err))
+
+	server, _, _ = newJSONLDTestServer(t, http.StatusInternalServerError, `Internal Server Error`)
+	err = newTestConnector(t, server.URL, managementAPIV3).Assets.DeleteAsset("asset-1")
+	assert.ErrorContains(t, err, "Internal Server Error")
+	assert.Nil(t, apiViolations(err))
+}
+
+func Test_jsonldAssets_dataAddressKeys(t *testing.T) {
+	input := assetInput{
+		Properties: map[string]interface{}{"asset:prop:id": "asset-1"},
+		DataAddress: map[string]interface{}{
+			"type":            "HttpData",
+			"header:Accept":   "application/json",
+			"oauth2:tokenUrl": "https://example.com/token",
+			"edc:baseUrl":     "https://example.com",
+		},
+	}
+
+	server, _, payload := newJSONLDTestServer(t, http.StatusOK, `{"@id": "asset-1"}`)
+	_, err := newTestConnector(t, server.URL, managementAPIV3).Assets.CreateAsset(input)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"@type":                          "DataAddress",
+		"type":                           "HttpData",
+		edcNamespace + "header:Accept":   "application/json",
+		edcNamespace + "oauth2:tokenUrl": "https://example.com/token",
+		"edc:baseUrl":                    "https://example.com",
+	}, (*payload)["dataAddress"])
+
+	server, _, _ = newJSONLDTestServer(t, http.StatusOK, `{
+		"@id": "asset-1",
+		"edc:properties": {},
+		"edc:dataAddress": {
+			"@type": "edc:DataAddress",
+			"edc:type": "HttpData",
+			"edc:header:Accept": "application/json",
+			"https://w3id.org/edc/v0.0.1/ns/oauth2:tokenUrl": "https://example.com/token"
+		},
+		"@context": {"edc": "https://w3id.org/edc/v0.0.1/ns/"}
+	}`)
+	dataAddress, err := newTestConnector(t, server.URL, managementAPIV3).Assets.GetAssetDataAddress("asset-1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"type":            "HttpData",
+		"header:Accept":   "application/json",
+		"oauth2:tokenUrl": "https://example.com/token",
+	}, dataAddress.AssetProperties)
+}
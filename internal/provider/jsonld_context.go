@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// jsonldPrefixes maps the prefixes usable in asset property keys, such as
+// "dct" in "dct:type", to the namespace IRI they stand for.
+type jsonldPrefixes map[string]string
+
+// defaultJSONLDPrefixes are always defined. Unprefixed keys belong to the EDC
+// namespace.
+var defaultJSONLDPrefixes = jsonldPrefixes{
+	"edc":  edcNamespace,
+	"odrl": odrlNamespace,
+}
+
+var (
+	jsonldPrefixPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]*$`)
+	absoluteIRIPattern  = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*:\S+$`)
+)
+
+// contextDescription documents the context attributes of the provider and of
+// the resources.
+const contextDescription = "JSON-LD prefixes usable in asset property keys, mapped to the namespace IRI they stand for, " +
+	"such as `dct = \"http://purl.org/dc/terms/\"`. The `edc` and `odrl` prefixes are always defined, and keys without prefix belong to the EDC namespace. " +
+	"Property keys are expanded against the context before being sent to the JSON-LD management APIs, " +
+	"and the keys read back are matched with the configured keys through their expansion, so that `name`, `edc:name`, `asset:prop:name` and " +
+	"`https://w3id.org/edc/v0.0.1/ns/name` denote the same property. The legacy v1 management API keeps the keys as configured."
+
+// contextValidators validates the prefixes of context attributes. The "asset"
+// prefix is reserved for the "asset:prop:" keys of the v1 API.
+func contextValidators() []validator.Map {
+	return []validator.Map{
+		mapvalidator.KeysAre(
+			stringvalidator.RegexMatches(jsonldPrefixPattern, "must be a JSON-LD prefix"),
+			stringvalidator.NoneOf("asset"),
+		),
+		mapvalidator.ValueStringsAre(
+			stringvalidator.RegexMatches(absoluteIRIPattern, "must be an absolute IRI"),
+		),
+	}
+}
+
+// validateContext returns the prefixes of the context configured for a
+// connector.
+func validateContext(ctx context.Context, data ConnectorModel, root path.Path, diags *diag.Diagnostics) jsonldPrefixes {
+	if data.Context.IsUnknown() {
+		diags.AddAttributeError(
+			root.AtName("context"),
+			"Unknown JSON-LD Context",
+			"The provider cannot expand asset property keys as there is an unknown configuration value for the JSON-LD context. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+		return nil
+	}
+
+	var prefixes jsonldPrefixes
+	if !data.Context.IsNull() {
+		diags.Append(data.Context.ElementsAs(ctx, &prefixes, false)...)
+	}
+	return prefixes
+}
+
+// with returns the prefixes completed, or overridden, by the given ones.
+func (p jsonldPrefixes) with(other map[string]string) jsonldPrefixes {
+	merged := make(jsonldPrefixes, len(defaultJSONLDPrefixes)+len(p)+len(other))
+	for _, prefixes := range []map[string]string{defaultJSONLDPrefixes, p, other} {
+		for prefix, namespace := range prefixes {
+			merged[prefix] = namespace
+		}
+	}
+	return merged
+}
+
+// expand returns the IRI of a property key. Keys prefixed by "asset:prop:"
+// and keys without prefix belong to the EDC namespace, keys with an unknown
+// prefix are taken as IRIs.
+func (p jsonldPrefixes) expand(key string) string {
+	if strings.HasPrefix(key, legacyAssetPropertyPrefix) {
+		return edcNamespace + strings.TrimPrefix(key, legacyAssetPropertyPrefix)
+	}
+
+	prefix, local, found := strings.Cut(key, ":")
+	if !found {
+		return edcNamespace + key
+	}
+	if namespace, ok := p[prefix]; ok && !strings.HasPrefix(local, "//") {
+		return namespace + local
+	}
+	return key
+}
+
+// compact returns the shortest prefixed form of an IRI, or the IRI when no
+// prefix matches it. Properties of the EDC namespace keep their v1 key.
+func (p jsonldPrefixes) compact(iri string) string {
+	if strings.HasPrefix(iri, edcNamespace) {
+		return legacyAssetPropertyPrefix + strings.TrimPrefix(iri, edcNamespace)
+	}
+
+	prefixes := make([]string, 0, len(p))
+	for prefix := range p {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	compacted := iri
+	for _, prefix := range prefixes {
+		namespace := p[prefix]
+		if namespace == edcNamespace || !strings.HasPrefix(iri, namespace) {
+			continue
+		}
+		if candidate := prefix + ":" + strings.TrimPrefix(iri, namespace); len(candidate) < len(compacted) {
+			compacted = candidate
+		}
+	}
+	return compacted
+}
+
+// expandKeys returns the properties keyed by their IRI.
+func (p jsonldPrefixes) expandKeys(properties map[string]interface{}) map[string]interface{} {
+	expanded := make(map[string]interface{}, len(properties))
+	for key, value := range properties {
+		expanded[p.expand(key)] = value
+	}
+	return expanded
+}
+
+// normalizeKeys returns the properties read from the connector keyed as in
+// the prior properties when they denote the same IRI, and compacted against
+// the prefixes otherwise.
+func (p jsonldPrefixes) normalizeKeys(properties map[string]interface{}, prior map[string]interface{}) map[string]interface{} {
+	priorKeys := make(map[string]string, len(prior))
+	for key := range prior {
+		priorKeys[p.expand(key)] = key
+	}
+
+	normalized := make(map[string]interface{}, len(properties))
+	for key, value := range properties {
+		if _, ok := prior[key]; ok {
+			normalized[key] = value
+			continue
+		}
+
+		iri := p.expand(key)
+		if priorKey, ok := priorKeys[iri]; ok {
+			normalized[priorKey] = value
+			continue
+		}
+		normalized[p.compact(iri)] = value
+	}
+	return normalized
+}
+
+// isAssetIdKey reports whether the property key sets the id of the asset.
+func (p jsonldPrefixes) isAssetIdKey(key string) bool {
+	return p.expand(key) == edcNamespace+"id"
+}
+
+// hasAssetId reports whether the properties set the id of the asset.
+func (p jsonldPrefixes) hasAssetId(properties map[string]interface{}) bool {
+	for key := range properties {
+		if p.isAssetIdKey(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// contextPrefixes returns the prefixes of the connector completed by the
+// context of a resource.
+func (c *EDCConnector) contextPrefixes(ctx context.Context, resourceContext types.Map, diags *diag.Diagnostics) jsonldPrefixes {
+	var prefixes map[string]string
+	if !resourceContext.IsNull() && !resourceContext.IsUnknown() {
+		diags.Append(resourceContext.ElementsAs(ctx, &prefixes, false)...)
+	}
+	return c.Prefixes.with(prefixes)
+}
+
+// usesJSONLD reports whether the connector serves a JSON-LD management API,
+// which expects property keys to be IRIs.
+func (c *EDCConnector) usesJSONLD() bool {
+	return c.ManagementAPIVersion != managementAPIV1
+}
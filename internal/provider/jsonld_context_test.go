@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_jsonldPrefixes_expand(t *testing.T) {
+	prefixes := jsonldPrefixes{"dct": "http://purl.org/dc/terms/"}.with(nil)
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{key: "name", expected: edcNamespace + "name"},
+		{key: "edc:name", expected: edcNamespace + "name"},
+		{key: "asset:prop:name", expected: edcNamespace + "name"},
+		{key: edcNamespace + "name", expected: edcNamespace + "name"},
+		{key: "dct:type", expected: "http://purl.org/dc/terms/type"},
+		{key: "http://purl.org/dc/terms/type", expected: "http://purl.org/dc/terms/type"},
+		{key: "unknown:type", expected: "unknown:type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			assert.Equal(t, tt.expected, prefixes.expand(tt.key))
+		})
+	}
+}
+
+func Test_jsonldPrefixes_compact(t *testing.T) {
+	prefixes := jsonldPrefixes{
+		"dc":  "http://purl.org/dc/",
+		"dct": "http://purl.org/dc/terms/",
+	}.with(nil)
+
+	assert.Equal(t, "asset:prop:name", prefixes.compact(edcNamespace+"name"))
+	assert.Equal(t, "dct:type", prefixes.compact("http://purl.org/dc/terms/type"))
+	assert.Equal(t, "https://example.com/type", prefixes.compact("https://example.com/type"))
+}
+
+func Test_jsonldPrefixes_normalizeKeys(t *testing.T) {
+	prefixes := defaultJSONLDPrefixes.with(map[string]string{"dct": "http://purl.org/dc/terms/"})
+
+	tests := []struct {
+		name     string
+		read     map[string]interface{}
+		prior    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:     "keys of the prior state",
+			read:     map[string]interface{}{"asset:prop:name": "a", "asset:prop:version": 1.0, "http://purl.org/dc/terms/type": "t"},
+			prior:    map[string]interface{}{"name": "a", "edc:version": 1.0, "dct:type": "t"},
+			expected: map[string]interface{}{"name": "a", "edc:version": 1.0, "dct:type": "t"},
+		},
+		{
+			name:     "identical keys",
+			read:     map[string]interface{}{"asset:prop:name": "a"},
+			prior:    map[string]interface{}{"asset:prop:name": "b"},
+			expected: map[string]interface{}{"asset:prop:name": "a"},
+		},
+		{
+			name:     "without prior state",
+			read:     map[string]interface{}{"asset:prop:name": "a", "http://purl.org/dc/terms/type": "t", "https://example.com/p": "p"},
+			expected: map[string]interface{}{"asset:prop:name": "a", "dct:type": "t", "https://example.com/p": "p"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, prefixes.normalizeKeys(tt.read, tt.prior))
+		})
+	}
+}
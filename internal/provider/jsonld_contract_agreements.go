@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"net/http"
+)
+
+// jsonldContractAgreements implements contractAgreementsAPI on top of the
+// JSON-LD management API.
+type jsonldContractAgreements struct {
+	client *managementClient
+}
+
+var _ contractAgreementsAPI = &jsonldContractAgreements{}
+
+// jsonldContractAgreement is the compacted representation of a contract
+// agreement.
+type jsonldContractAgreement struct {
+	Id      string `json:"@id"`
+	AssetId string `json:"assetId"`
+}
+
+func (c *jsonldContractAgreements) ListContractAgreements() ([]contractAgreement, error) {
+	var outputs []contractAgreement
+	for offset := 0; ; offset += queryPageSize {
+		var page []jsonldContractAgreement
+		if err := c.client.do(http.MethodPost, "/contractagreements/request", c.client.querySpec(offset), &page, http.StatusOK); err != nil {
+			return nil, err
+		}
+		for _, agreement := range page {
+			outputs = append(outputs, contractAgreement(agreement))
+		}
+		if len(page) < queryPageSize {
+			return outputs, nil
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Think-iT-Labs/edc-connector-client-go/service/contractdefinition"
+)
+
+// jsonldContractDefinitions implements contractDefinitionsAPI on top of the
+// JSON-LD management API. The JSON-LD APIs have no validity, which is
+// therefore neither sent nor read.
+type jsonldContractDefinitions struct {
+	client *managementClient
+}
+
+var _ contractDefinitionsAPI = &jsonldContractDefinitions{}
+
+// jsonldContractDefinition is the compacted representation of a contract
+// definition.
+type jsonldContractDefinition struct {
+	Id               string      `json:"@id"`
+	CreatedAt        int64       `json:"createdAt"`
+	AccessPolicyId   string      `json:"accessPolicyId"`
+	ContractPolicyId string      `json:"contractPolicyId"`
+	AssetsSelector   interface{} `json:"assetsSelector"`
+}
+
+func (c *jsonldContractDefinitions) CreateContractDefinition(cd contractDefinition) (*contractdefinition.CreateContractDefinitionOutput, error) {
+	assetsSelector := make([]map[string]interface{}, 0, len(cd.Criteria))
+	for _, criterion := range cd.Criteria {
+		assetsSelector = append(assetsSelector, map[string]interface{}{
+			"@type":        "Criterion",
+			"operandLeft":  toJSONLDOperandLeft(criterion.OperandLeft),
+			"operator":     criterion.Operator,
+			"operandRight": criterion.OperandRight,
+		})
+	}
+
+	payload := map[string]interface{}{
+		"@context":         jsonldContext,
+		"@type":            "ContractDefinition",
+		"accessPolicyId":   cd.AccessPolicyId,
+		"contractPolicyId": cd.ContractPolicyId,
+		"assetsSelector":   assetsSelector,
+	}
+	if cd.Id != "" {
+		payload["@id"] = cd.Id
+	}
+
+	var response idResponse
+	if err := c.client.do(http.MethodPost, "/contractdefinitions", payload, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &contractdefinition.CreateContractDefinitionOutput{
+		Id:        response.Id,
+		CreatedAt: response.CreatedAt,
+	}, nil
+}
+
+func (c *jsonldContractDefinitions) GetContractDefinition(contractDefinitionId string) (*contractDefinition, error) {
+	var cd jsonldContractDefinition
+	if err := c.client.do(http.MethodGet, "/contractdefinitions/"+url.PathEscape(contractDefinitionId), nil, &cd, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return cd.output(), nil
+}
+
+func (c *jsonldContractDefinitions) ListContractDefinitions() ([]contractDefinition, error) {
+	var outputs []contractDefinition
+	for offset := 0; ; offset += queryPageSize {
+		var page []jsonldContractDefinition
+		if err := c.client.do(http.MethodPost, "/contractdefinitions/request", c.client.querySpec(offset), &page, http.StatusOK); err != nil {
+			return nil, err
+		}
+		for _, cd := range page {
+			outputs = append(outputs, *cd.output())
+		}
+		if len(page) < queryPageSize {
+			return outputs, nil
+		}
+	}
+}
+
+// output returns the contract definition in its v1 representation.
+func (cd *jsonldContractDefinition) output() *contractDefinition {
+	var criteria []criterion
+	for _, element := range asList(cd.AssetsSelector) {
+		object, ok := element.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		criteria = append(criteria, criterion{
+			OperandLeft:  fromJSONLDOperandLeft(stringValue(object["operandLeft"])),
+			Operator:     stringValue(object["operator"]),
+			OperandRight: operandRightValue(object["operandRight"]),
+		})
+	}
+
+	return &contractDefinition{
+		Id:               cd.Id,
+		AccessPolicyId:   cd.AccessPolicyId,
+		ContractPolicyId: cd.ContractPolicyId,
+		Criteria:         criteria,
+		CreatedAt:        cd.CreatedAt,
+	}
+}
+
+func (c *jsonldContractDefinitions) DeleteContractDefinition(contractDefinitionId string) error {
+	return c.client.do(http.MethodDelete, "/contractdefinitions/"+url.PathEscape(contractDefinitionId), nil, nil, http.StatusNoContent)
+}
+
+// toJSONLDOperandLeft expands the v1 asset properties selected by criteria,
+// such as "asset:prop:id", to their IRI.
+func toJSONLDOperandLeft(operandLeft string) string {
+	if key := fromLegacyAssetPropertyKey(operandLeft); key != operandLeft {
+		return edcNamespace + key
+	}
+	return operandLeft
+}
+
+func fromJSONLDOperandLeft(operandLeft string) string {
+	if strings.HasPrefix(operandLeft, edcNamespace) {
+		return legacyAssetPropertyPrefix + strings.TrimPrefix(operandLeft, edcNamespace)
+	}
+	return operandLeft
+}
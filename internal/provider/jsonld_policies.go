@@ -0,0 +1,288 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/Think-iT-Labs/edc-connector-client-go/service/policies"
+)
+
+// jsonldPolicies implements policiesAPI on top of the JSON-LD management API.
+type jsonldPolicies struct {
+	client *managementClient
+}
+
+var _ policiesAPI = &jsonldPolicies{}
+
+// odrlTerms maps the fields of the v1 policy representation to the ODRL
+// terms of the JSON-LD APIs.
+var odrlTerms = map[string]string{
+	"permissions":      "odrl:permission",
+	"prohibitions":     "odrl:prohibition",
+	"obligations":      "odrl:obligation",
+	"duties":           "odrl:duty",
+	"constraints":      "odrl:constraint",
+	"consequence":      "odrl:consequence",
+	"action":           "odrl:action",
+	"assignee":         "odrl:assignee",
+	"assigner":         "odrl:assigner",
+	"target":           "odrl:target",
+	"inheritsFrom":     "odrl:inheritFrom",
+	"includedIn":       "odrl:includedIn",
+	"constraint":       "odrl:refinement",
+	"type":             "odrl:type",
+	"uid":              "@id",
+	"edctype":          "@type",
+	"parentPermission": "edc:parentPermission",
+	"remedies":         "odrl:remedy",
+}
+
+// odrlListTerms lists the v1 fields holding lists, which JSON-LD compacts to
+// a single value when they hold one element.
+var odrlListTerms = map[string]bool{
+	"permissions":  true,
+	"prohibitions": true,
+	"obligations":  true,
+	"duties":       true,
+	"constraints":  true,
+	"remedies":     true,
+}
+
+// odrlObjectTerms lists the v1 fields holding objects. The other fields hold
+// strings, which JSON-LD may return as node references.
+var odrlObjectTerms = map[string]bool{
+	"action":           true,
+	"consequence":      true,
+	"constraint":       true,
+	"parentPermission": true,
+}
+
+// odrlPolicyTypes maps the v1 policy types to the ODRL policy classes.
+var odrlPolicyTypes = map[policies.PolicyType]string{
+	policies.SetPolicyType:      "odrl:Set",
+	policies.OfferPolicyType:    "odrl:Offer",
+	policies.ContractPolicyType: "odrl:Agreement",
+}
+
+const legacyPolicyTypeKey = "@policytype"
+
+// jsonldPolicyDefinition is the compacted representation of a policy
+// definition.
+type jsonldPolicyDefinition struct {
+	Id        string                 `json:"@id"`
+	CreatedAt int64                  `json:"createdAt"`
+	Policy    map[string]interface{} `json:"policy"`
+}
+
+func (p *jsonldPolicies) CreatePolicy(createPolicyInput policyInput) (*policies.CreatePolicyOutput, error) {
+	policy, err := toODRLPolicy(createPolicyInput.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"@context": jsonldContext,
+		"@type":    "PolicyDefinition",
+		"policy":   policy,
+	}
+	if createPolicyInput.Id != nil {
+		payload["@id"] = *createPolicyInput.Id
+	}
+
+	var response idResponse
+	if err := p.client.do(http.MethodPost, "/policydefinitions", payload, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &policies.CreatePolicyOutput{
+		Id:        response.Id,
+		CreatedAt: response.CreatedAt,
+	}, nil
+}
+
+func (p *jsonldPolicies) GetPolicy(policyId string) (*policyOutput, error) {
+	var definition jsonldPolicyDefinition
+	if err := p.client.do(http.MethodGet, "/policydefinitions/"+url.PathEscape(policyId), nil, &definition, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	policy, err := fromODRLPolicy(definition.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &policyOutput{
+		Id:        definition.Id,
+		CreatedAt: definition.CreatedAt,
+		Policy:    *policy,
+	}, nil
+}
+
+func (p *jsonldPolicies) DeletePolicy(policyId string) error {
+	return p.client.do(http.MethodDelete, "/policydefinitions/"+url.PathEscape(policyId), nil, nil, http.StatusNoContent)
+}
+
+// toODRLPolicy translates the v1 representation of a policy to ODRL.
+func toODRLPolicy(policy legacyPolicy) (map[string]interface{}, error) {
+	policyType := policies.SetPolicyType
+	if t, ok := policy.Type[legacyPolicyTypeKey]; ok {
+		policyType = t
+	}
+
+	// The policy type and the extensible properties are not ODRL terms.
+	extensibleProperties := policy.ExtensibleProperties
+	policy.Type = nil
+	policy.ExtensibleProperties = nil
+
+	content, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	var legacy map[string]interface{}
+	if err := json.Unmarshal(content, &legacy); err != nil {
+		return nil, err
+	}
+
+	odrl, _ := renameTerms(legacy, func(key string) string {
+		if term, ok := odrlTerms[key]; ok {
+			return term
+		}
+		return key
+	}).(map[string]interface{})
+
+	odrl["@type"] = odrlPolicyTypes[policyType]
+	if extensibleProperties != nil {
+		for key, value := range *extensibleProperties {
+			odrl[key] = value
+		}
+	}
+	return odrl, nil
+}
+
+// fromODRLPolicy translates a compacted ODRL policy to its v1 representation.
+func fromODRLPolicy(odrl map[string]interface{}) (*legacyPolicy, error) {
+	legacyTerms := make(map[string]string, len(odrlTerms))
+	for legacyKey, term := range odrlTerms {
+		legacyTerms[compactIRI(term)] = legacyKey
+	}
+
+	policy := &legacyPolicy{}
+	policy.Type = map[string]policies.PolicyType{legacyPolicyTypeKey: policies.SetPolicyType}
+	extensibleProperties := policies.ExtensibleProperties{}
+	translated := map[string]interface{}{}
+
+	for key, value := range odrl {
+		if key == "@type" {
+			for policyType, class := range odrlPolicyTypes {
+				if compactIRI(class) == stringValue(value) {
+					policy.Type[legacyPolicyTypeKey] = policyType
+				}
+			}
+			continue
+		}
+
+		legacyKey, ok := legacyTerms[key]
+		if !ok {
+			extensibleProperties[key] = stringValue(value)
+			continue
+		}
+		translated[legacyKey] = fromODRLValue(legacyKey, value, legacyTerms)
+	}
+
+	content, err := json.Marshal(translated)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, policy); err != nil {
+		return nil, err
+	}
+
+	if len(extensibleProperties) != 0 {
+		policy.ExtensibleProperties = &extensibleProperties
+	}
+	return policy, nil
+}
+
+func fromODRLValue(legacyKey string, value interface{}, legacyTerms map[string]string) interface{} {
+	if odrlListTerms[legacyKey] {
+		elements := asList(value)
+		translated := make([]interface{}, len(elements))
+		for i, element := range elements {
+			translated[i] = fromODRLObject(element, legacyTerms)
+		}
+		return translated
+	}
+
+	if legacyKey == "action" {
+		return fromODRLAction(value, legacyTerms)
+	}
+
+	if !odrlObjectTerms[legacyKey] {
+		if reference, ok := value.(map[string]interface{}); ok {
+			if id, ok := reference["@id"]; ok {
+				return stringValue(id)
+			}
+		}
+		return stringValue(value)
+	}
+	return fromODRLObject(value, legacyTerms)
+}
+
+func fromODRLObject(value interface{}, legacyTerms map[string]string) interface{} {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+
+	translated := make(map[string]interface{}, len(object))
+	for key, element := range object {
+		legacyKey, ok := legacyTerms[key]
+		if !ok {
+			continue
+		}
+		translated[legacyKey] = fromODRLValue(legacyKey, element, legacyTerms)
+	}
+	return translated
+}
+
+// fromODRLAction translates actions, which the JSON-LD APIs may return as a
+// bare action IRI.
+func fromODRLAction(value interface{}, legacyTerms map[string]string) interface{} {
+	switch action := value.(type) {
+	case string:
+		return map[string]interface{}{"type": action}
+	case map[string]interface{}:
+		translated, _ := fromODRLObject(action, legacyTerms).(map[string]interface{})
+		if _, ok := translated["type"]; !ok {
+			if id, ok := action["@id"].(string); ok {
+				translated["type"] = id
+			}
+		}
+		delete(translated, "uid")
+		return translated
+	default:
+		return value
+	}
+}
+
+// renameTerms renames the keys of every object of a JSON document.
+func renameTerms(value interface{}, rename func(string) string) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		renamed := make(map[string]interface{}, len(typed))
+		for key, element := range typed {
+			renamed[rename(key)] = renameTerms(element, rename)
+		}
+		return renamed
+	case []interface{}:
+		renamed := make([]interface{}, len(typed))
+		for i, element := range typed {
+			renamed[i] = renameTerms(element, rename)
+		}
+		return renamed
+	default:
+		return value
+	}
+}
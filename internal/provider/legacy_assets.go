@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/Think-iT-Labs/edc-connector-client-go/service/assets"
+)
+
+// legacyAssets implements assetsAPI on top of the v1 management API. The EDC
+// client only supports string property values, so assets are created and
+// read without it. The v1 API has no private properties.
+type legacyAssets struct {
+	sdk    *assets.Client
+	client *managementClient
+}
+
+var _ assetsAPI = &legacyAssets{}
+
+// legacyAsset is the representation of an asset in the v1 API.
+type legacyAsset struct {
+	Id         string                 `json:"id"`
+	CreatedAt  int64                  `json:"createdAt"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+func (a *legacyAssets) CreateAsset(createAssetInput assetInput) (*assets.CreateAssetOutput, error) {
+	if len(createAssetInput.PrivateProperties) != 0 {
+		return nil, fmt.Errorf("the v1 management API does not support private properties")
+	}
+
+	payload := map[string]interface{}{
+		"asset":       map[string]interface{}{"properties": createAssetInput.Properties},
+		"dataAddress": map[string]interface{}{"properties": createAssetInput.DataAddress},
+	}
+
+	var response legacyAsset
+	if err := a.client.do(http.MethodPost, "/assets", payload, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &assets.CreateAssetOutput{
+		Id:        response.Id,
+		CreatedAt: response.CreatedAt,
+	}, nil
+}
+
+func (a *legacyAssets) GetAsset(assetId string) (*assetOutput, error) {
+	var asset legacyAsset
+	if err := a.client.do(http.MethodGet, "/assets/"+url.PathEscape(assetId), nil, &asset, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &assetOutput{
+		Id:         asset.Id,
+		CreatedAt:  asset.CreatedAt,
+		Properties: asset.Properties,
+	}, nil
+}
+
+func (a *legacyAssets) ListAssets() ([]assetOutput, error) {
+	var outputs []assetOutput
+	for offset := 0; ; offset += queryPageSize {
+		var page []legacyAsset
+		if err := a.client.do(http.MethodPost, "/assets/request", a.client.querySpec(offset), &page, http.StatusOK); err != nil {
+			return nil, err
+		}
+		for _, asset := range page {
+			outputs = append(outputs, assetOutput{
+				Id:         asset.Id,
+				CreatedAt:  asset.CreatedAt,
+				Properties: asset.Properties,
+			})
+		}
+		if len(page) < queryPageSize {
+			return outputs, nil
+		}
+	}
+}
+
+func (a *legacyAssets) GetAssetDataAddress(assetId string) (*assets.AssetDataAddressOutput, error) {
+	return a.sdk.GetAssetDataAddress(assetId)
+}
+
+func (a *legacyAssets) DeleteAsset(assetId string) error {
+	return a.sdk.DeleteAsset(assetId)
+}
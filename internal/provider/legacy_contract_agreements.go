@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"net/http"
+)
+
+// legacyContractAgreements implements contractAgreementsAPI on top of the v1
+// management API.
+type legacyContractAgreements struct {
+	client *managementClient
+}
+
+var _ contractAgreementsAPI = &legacyContractAgreements{}
+
+func (c *legacyContractAgreements) ListContractAgreements() ([]contractAgreement, error) {
+	var outputs []contractAgreement
+	for offset := 0; ; offset += queryPageSize {
+		var page []contractAgreement
+		if err := c.client.do(http.MethodPost, "/contractagreements/request", c.client.querySpec(offset), &page, http.StatusOK); err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, page...)
+		if len(page) < queryPageSize {
+			return outputs, nil
+		}
+	}
+}
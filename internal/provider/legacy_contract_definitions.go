@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/Think-iT-Labs/edc-connector-client-go/service/contractdefinition"
+)
+
+// legacyContractDefinitions implements contractDefinitionsAPI on top of the v1
+// management API. The EDC client only supports string operands, so contract
+// definitions are created and read without it.
+type legacyContractDefinitions struct {
+	sdk    *contractdefinition.Client
+	client *managementClient
+}
+
+var _ contractDefinitionsAPI = &legacyContractDefinitions{}
+
+func (c *legacyContractDefinitions) CreateContractDefinition(cd contractDefinition) (*contractdefinition.CreateContractDefinitionOutput, error) {
+	if cd.Criteria == nil {
+		cd.Criteria = []criterion{}
+	}
+
+	var response contractdefinition.CreateContractDefinitionOutput
+	if err := c.client.do(http.MethodPost, "/contractdefinitions", cd, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *legacyContractDefinitions) GetContractDefinition(contractDefinitionId string) (*contractDefinition, error) {
+	var cd contractDefinition
+	if err := c.client.do(http.MethodGet, "/contractdefinitions/"+url.PathEscape(contractDefinitionId), nil, &cd, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	cd.normalizeOperands()
+	return &cd, nil
+}
+
+func (c *legacyContractDefinitions) ListContractDefinitions() ([]contractDefinition, error) {
+	var outputs []contractDefinition
+	for offset := 0; ; offset += queryPageSize {
+		var page []contractDefinition
+		if err := c.client.do(http.MethodPost, "/contractdefinitions/request", c.client.querySpec(offset), &page, http.StatusOK); err != nil {
+			return nil, err
+		}
+		for _, cd := range page {
+			cd.normalizeOperands()
+			outputs = append(outputs, cd)
+		}
+		if len(page) < queryPageSize {
+			return outputs, nil
+		}
+	}
+}
+
+// normalizeOperands reads the right operands of the criteria as strings, or
+// lists of strings.
+func (cd *contractDefinition) normalizeOperands() {
+	for i, criterion := range cd.Criteria {
+		cd.Criteria[i].OperandRight = operandRightValue(criterion.OperandRight)
+	}
+}
+
+func (c *legacyContractDefinitions) DeleteContractDefinition(contractDefinitionId string) error {
+	return c.sdk.DeleteContractDefinition(contractDefinitionId)
+}
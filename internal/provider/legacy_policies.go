@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/Think-iT-Labs/edc-connector-client-go/service/policies"
+)
+
+// legacyPolicies implements policiesAPI on top of the v1 management API. The
+// EDC client drops the remedies of prohibitions, so policies are created and
+// read through the management client, which keeps them.
+type legacyPolicies struct {
+	sdk    *policies.Client
+	client *managementClient
+}
+
+var _ policiesAPI = &legacyPolicies{}
+
+func (p *legacyPolicies) CreatePolicy(createPolicyInput policyInput) (*policies.CreatePolicyOutput, error) {
+	var response policies.CreatePolicyOutput
+	if err := p.client.do(http.MethodPost, "/policydefinitions", createPolicyInput, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (p *legacyPolicies) GetPolicy(policyId string) (*policyOutput, error) {
+	var definition policyOutput
+	if err := p.client.do(http.MethodGet, "/policydefinitions/"+url.PathEscape(policyId), nil, &definition, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+func (p *legacyPolicies) DeletePolicy(policyId string) error {
+	return p.sdk.DeletePolicy(policyId)
+}
@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Think-iT-Labs/edc-connector-client-go/service/assets"
+	"github.com/Think-iT-Labs/edc-connector-client-go/service/contractdefinition"
+	"github.com/Think-iT-Labs/edc-connector-client-go/service/policies"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// Versions of the management API. The legacy v1 API is called through the
+// EDC client, completed by managementClient where it falls short, and the
+// JSON-LD based v2 and v3 APIs through managementClient.
+const (
+	managementAPIAuto = "auto"
+	managementAPIV1   = "v1"
+	managementAPIV2   = "v2"
+	managementAPIV3   = "v3"
+)
+
+var managementAPIVersions = []string{managementAPIAuto, managementAPIV1, managementAPIV2, managementAPIV3}
+
+// assetsAPI is the part of the management API managing assets. Every version
+// of the API exchanges the legacy v1 representation of assets.
+type assetsAPI interface {
+	CreateAsset(asset assetInput) (*assets.CreateAssetOutput, error)
+	GetAsset(assetId string) (*assetOutput, error)
+	ListAssets() ([]assetOutput, error)
+	GetAssetDataAddress(assetId string) (*assets.AssetDataAddressOutput, error)
+	DeleteAsset(assetId string) error
+}
+
+// assetInput is an asset to create. Unlike the assets of the EDC client, the
+// values of its properties may be any JSON value. Private properties are only
+// supported by the JSON-LD APIs. The data address holds the properties sent
+// to the connector, including its type.
+type assetInput struct {
+	Properties        map[string]interface{}
+	PrivateProperties map[string]interface{}
+	DataAddress       map[string]interface{}
+}
+
+// queryPageSize is the number of objects requested at once when listing
+// assets, contract definitions or contract agreements.
+const queryPageSize = 100
+
+// assetOutput is an asset read from the management API.
+type assetOutput struct {
+	Id                string
+	CreatedAt         int64
+	Properties        map[string]interface{}
+	PrivateProperties map[string]interface{}
+}
+
+// policiesAPI is the part of the management API managing policy definitions.
+// Every version of the API exchanges the legacy v1 representation of
+// policies.
+type policiesAPI interface {
+	CreatePolicy(createPolicyInput policyInput) (*policies.CreatePolicyOutput, error)
+	GetPolicy(policyId string) (*policyOutput, error)
+	DeletePolicy(policyId string) error
+}
+
+// legacyPolicy is the v1 representation of a policy. Unlike the policies of
+// the EDC client, its prohibitions hold their remedies.
+type legacyPolicy struct {
+	policies.Policy
+	Prohibitions *[]legacyProhibition `json:"prohibitions,omitempty"`
+}
+
+// legacyProhibition is a prohibition with the ODRL duties remedying its
+// violation, which the EDC client does not define.
+type legacyProhibition struct {
+	policies.Prohibition
+	Remedies *[]policies.Duty `json:"remedies,omitempty"`
+}
+
+// policyInput is a policy definition to create.
+type policyInput struct {
+	Id     *string      `json:"id,omitempty"`
+	Policy legacyPolicy `json:"policy"`
+}
+
+// policyOutput is a policy definition read from the management API.
+type policyOutput struct {
+	Id        string       `json:"id"`
+	CreatedAt int64        `json:"createdAt"`
+	Policy    legacyPolicy `json:"policy"`
+}
+
+// contractDefinitionsAPI is the part of the management API managing contract
+// definitions.
+type contractDefinitionsAPI interface {
+	CreateContractDefinition(cd contractDefinition) (*contractdefinition.CreateContractDefinitionOutput, error)
+	GetContractDefinition(contractDefinitionId string) (*contractDefinition, error)
+	ListContractDefinitions() ([]contractDefinition, error)
+	DeleteContractDefinition(contractDefinitionId string) error
+}
+
+// contractAgreementsAPI is the part of the management API reading contract
+// agreements.
+type contractAgreementsAPI interface {
+	ListContractAgreements() ([]contractAgreement, error)
+}
+
+// contractAgreement is a contract agreement of the management API, reduced
+// to the asset it grants access to.
+type contractAgreement struct {
+	Id      string `json:"id"`
+	AssetId string `json:"assetId"`
+}
+
+// contractDefinition is a contract definition of the management API. Unlike
+// the contract definitions of the EDC client, its criteria may select a list
+// of values. It is the payload of the v1 API.
+type contractDefinition struct {
+	Id               string      `json:"id,omitempty"`
+	AccessPolicyId   string      `json:"accessPolicyId"`
+	ContractPolicyId string      `json:"contractPolicyId"`
+	Validity         int64       `json:"validity,omitempty"`
+	Criteria         []criterion `json:"criteria"`
+	CreatedAt        int64       `json:"createdAt,omitempty"`
+}
+
+// criterion selects the assets of a contract definition. The right operand is
+// a string, or a list of strings for the "in" operator.
+type criterion struct {
+	OperandLeft  string      `json:"operandLeft"`
+	Operator     string      `json:"operator"`
+	OperandRight interface{} `json:"operandRight,omitempty"`
+}
+
+// operandRightValue returns the right operand of a criterion read from the
+// management API as a string, or as a list of strings.
+func operandRightValue(operandRight interface{}) interface{} {
+	list, ok := operandRight.([]interface{})
+	if !ok {
+		if operandRight == nil {
+			return nil
+		}
+		return stringValue(operandRight)
+	}
+
+	values := make([]string, len(list))
+	for i, value := range list {
+		values[i] = stringValue(value)
+	}
+	return values
+}
+
+// validateManagementAPIVersion returns the management API version configured
+// for a connector, "auto" by default.
+func validateManagementAPIVersion(data ConnectorModel, root path.Path, useEnv bool, diags *diag.Diagnostics) string {
+	if data.ManagementAPIVersion.IsUnknown() {
+		diags.AddAttributeError(
+			root.AtName("management_api_version"),
+			"Unknown EDC Management API Version",
+			"The provider cannot create the EDC API client as there is an unknown configuration value for the management API version. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the EDC_MANAGEMENT_API_VERSION environment variable.",
+		)
+		return ""
+	}
+
+	if !data.ManagementAPIVersion.IsNull() {
+		return data.ManagementAPIVersion.ValueString()
+	}
+
+	if !useEnv {
+		return managementAPIAuto
+	}
+
+	version := os.Getenv("EDC_MANAGEMENT_API_VERSION")
+	if version == "" {
+		return managementAPIAuto
+	}
+
+	for _, supported := range managementAPIVersions {
+		if version == supported {
+			return version
+		}
+	}
+
+	diags.AddAttributeError(
+		root.AtName("management_api_version"),
+		"Invalid EDC_MANAGEMENT_API_VERSION Environment Variable",
+		fmt.Sprintf("The EDC_MANAGEMENT_API_VERSION environment variable must be one of %q, got %q.", managementAPIVersions, version),
+	)
+	return ""
+}
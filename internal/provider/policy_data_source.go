@@ -4,9 +4,7 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/Think-iT-Labs/edc-connector-client-go/edc"
 	"github.com/Think-iT-Labs/edc-connector-client-go/service/policies"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -23,14 +21,15 @@ func NewPolicyDataSource() datasource.DataSource {
 
 // PolicyDataSource defines the data source implementation.
 type PolicyDataSource struct {
-	client *policies.Client
+	connectors *EDCConnectors
 }
 
 // AssetDataSourceModel describes the data source data model.
 type PolicyDataSourceModel struct {
 	Id        types.String `tfsdk:"id"`
 	*Policy   `tfsdk:"policy"`
-	CreatedAt types.Int64 `tfsdk:"created_at"`
+	CreatedAt types.Int64  `tfsdk:"created_at"`
+	Connector types.String `tfsdk:"connector"`
 }
 
 func (d *PolicyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -51,6 +50,7 @@ func (d *PolicyDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 			"created_at": schema.Int64Attribute{
 				Computed: true,
 			},
+			"connector": connectorDataSourceAttribute(),
 		},
 	}
 }
@@ -61,26 +61,17 @@ func (d *PolicyDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	cfg, ok := req.ProviderData.(*edc.Config)
+	connectors, ok := req.ProviderData.(*EDCConnectors)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *edc.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *EDCConnectors, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	client, err := policies.New(*cfg)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to initiate assets client",
-			fmt.Sprintf("Client Error: %v", err),
-		)
-		return
-	}
-
-	d.client = client
+	d.connectors = connectors
 }
 
 func (d *PolicyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -93,10 +84,17 @@ func (d *PolicyDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	policy, err := d.client.GetPolicy(data.Id.ValueString())
+	connector, diags := d.connectors.Connector(ctx, data.Connector)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := connector.Policies.GetPolicy(data.Id.ValueString())
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Policy, got error: %s", err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.Config.Schema, nil, "read Policy", err)
 		return
 	}
 
@@ -160,8 +158,9 @@ func toTFDuty(duty policies.Duty) *Duty {
 		tfDuty.UID = basetypes.NewStringPointerValue(duty.UID)
 	}
 
+	// The documents of the EDC client types always encode.
 	if duty.ParentPermission != nil {
-		tfDuty.ParentPermission = toTFPermission(*duty.ParentPermission)
+		tfDuty.ParentPermission, _ = newJSONStringValue(duty.ParentPermission)
 	}
 
 	if duty.Action != nil {
@@ -169,7 +168,7 @@ func toTFDuty(duty policies.Duty) *Duty {
 	}
 
 	if duty.Consequence != nil {
-		tfDuty.Consequence = toTFDuty(*duty.Consequence)
+		tfDuty.Consequence, _ = newJSONStringValue(duty.Consequence)
 	}
 
 	if duty.Constraints != nil {
@@ -231,7 +230,7 @@ func toTFPermission(permission policies.Permission) *Permission {
 
 }
 
-func toTFProhibition(prohibition policies.Prohibition) *Prohibition {
+func toTFProhibition(prohibition legacyProhibition) *Prohibition {
 	tfProhibition := &Prohibition{}
 
 	if prohibition.Assignee != nil {
@@ -262,21 +261,28 @@ func toTFProhibition(prohibition policies.Prohibition) *Prohibition {
 		tfProhibition.Constraints = &constraints
 	}
 
+	if prohibition.Remedies != nil {
+		var remedies []Duty
+		for _, remedy := range *prohibition.Remedies {
+			remedies = append(remedies, *toTFDuty(remedy))
+		}
+		tfProhibition.Remedies = &remedies
+	}
+
 	return tfProhibition
 
 }
 
-func toTFPolicyType(policyType map[string]policies.PolicyType) basetypes.MapValue {
-	var tfPolicyType = make(map[string]attr.Value, len(policyType))
-
-	for k, v := range policyType {
-		tfPolicyType[k] = types.StringValue(string(v))
+// toTFPolicyType returns the type of a policy read with the EDC client,
+// which defaults to set.
+func toTFPolicyType(policyType map[string]policies.PolicyType) basetypes.StringValue {
+	if t, ok := policyType[legacyPolicyTypeKey]; ok && t != "" {
+		return types.StringValue(string(t))
 	}
-
-	return basetypes.NewMapValueMust(types.StringType, tfPolicyType)
+	return types.StringValue(string(SetPolicyType))
 }
 
-func toTFPolicy(policy policies.Policy) *Policy {
+func toTFPolicy(policy legacyPolicy) *Policy {
 
 	tfPolicy := &Policy{}
 
@@ -304,9 +310,7 @@ func toTFPolicy(policy policies.Policy) *Policy {
 		tfPolicy.UID = basetypes.NewStringPointerValue(policy.UID)
 	}
 
-	if policy.Type != nil {
-		tfPolicy.Type = toTFPolicyType(policy.Type)
-	}
+	tfPolicy.Type = toTFPolicyType(policy.Type)
 
 	if policy.Permissions != nil {
 		var permissions []Permission
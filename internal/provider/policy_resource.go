@@ -2,17 +2,21 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
-	"github.com/Think-iT-Labs/edc-connector-client-go/edc"
 	"github.com/Think-iT-Labs/edc-connector-client-go/service/policies"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -23,18 +27,37 @@ type PolicyType string
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &PoliciesResource{}
 var _ resource.ResourceWithImportState = &PoliciesResource{}
+var _ resource.ResourceWithModifyPlan = &PoliciesResource{}
+var _ resource.ResourceWithUpgradeState = &PoliciesResource{}
 
 func NewPoliciesResource() resource.Resource {
 	return &PoliciesResource{}
 }
 
 const (
-	SetPolicyType      PolicyType = "set"
-	OfferPolicyType    PolicyType = "offer"
-	ContractPolicyType PolicyType = "contract"
-	MaxRecursionLevel  int        = 3
+	SetPolicyType       PolicyType = "set"
+	OfferPolicyType     PolicyType = "offer"
+	ContractPolicyType  PolicyType = "contract"
+	AgreementPolicyType PolicyType = "agreement"
 )
 
+// policyTypes are the values of the type attribute of policies.
+var policyTypes = []string{
+	string(SetPolicyType),
+	string(OfferPolicyType),
+	string(ContractPolicyType),
+	string(AgreementPolicyType),
+}
+
+// sdkType returns the policy type of the EDC client. The agreement type is
+// the ODRL name of the contract type.
+func (t PolicyType) sdkType() policies.PolicyType {
+	if t == AgreementPolicyType {
+		return policies.ContractPolicyType
+	}
+	return policies.PolicyType(t)
+}
+
 type ExtensibleProperties map[string]string
 
 type Constraint struct {
@@ -59,14 +82,14 @@ type Permission struct {
 }
 
 type Duty struct {
-	Assignee         types.String  `tfsdk:"assignee"`
-	Assigner         types.String  `tfsdk:"assigner"`
-	Consequence      *Duty         `tfsdk:"consequence"`
-	Target           types.String  `tfsdk:"target"`
-	UID              types.String  `tfsdk:"uid"`
-	Constraints      *[]Constraint `tfsdk:"constraints"`
-	ParentPermission *Permission   `tfsdk:"parent_permission"`
-	Action           *Action       `tfsdk:"action"`
+	Assignee         types.String    `tfsdk:"assignee"`
+	Assigner         types.String    `tfsdk:"assigner"`
+	Consequence      jsonStringValue `tfsdk:"consequence"`
+	Target           types.String    `tfsdk:"target"`
+	UID              types.String    `tfsdk:"uid"`
+	Constraints      *[]Constraint   `tfsdk:"constraints"`
+	ParentPermission jsonStringValue `tfsdk:"parent_permission"`
+	Action           *Action         `tfsdk:"action"`
 }
 
 type Prohibition struct {
@@ -76,11 +99,12 @@ type Prohibition struct {
 	UID         types.String  `tfsdk:"uid"`
 	Constraints *[]Constraint `tfsdk:"constraints"`
 	Action      *Action       `tfsdk:"action"`
+	Remedies    *[]Duty       `tfsdk:"remedies"`
 }
 
 type Policy struct {
 	UID                  types.String          `tfsdk:"uid"`
-	Type                 types.Map             `tfsdk:"type"`
+	Type                 types.String          `tfsdk:"type"`
 	Assignee             types.String          `tfsdk:"assignee"`
 	Assigner             types.String          `tfsdk:"assigner"`
 	ExtensibleProperties *ExtensibleProperties `tfsdk:"extensible_properties"`
@@ -99,8 +123,12 @@ type PolicyDefinition struct {
 
 // PolicyResourceModel describes the resource data model.
 type PolicyResourceModel struct {
-	Policy `tfsdk:"policy"`
-	Id     types.String `tfsdk:"id"`
+	Policy             `tfsdk:"policy"`
+	Id                 types.String `tfsdk:"id"`
+	CreatedAt          types.Int64  `tfsdk:"created_at"`
+	Connector          types.String `tfsdk:"connector"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	ForceDelete        types.Bool   `tfsdk:"force_delete"`
 }
 
 func (p *PoliciesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -111,20 +139,132 @@ func (p *PoliciesResource) Schema(ctx context.Context, req resource.SchemaReques
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Policy resource",
+		Version:             2,
 
 		Attributes: map[string]schema.Attribute{
-			"policy": PolicySchema(),
+			"policy":              PolicySchema(),
+			"connector":           connectorResourceAttribute(),
+			"deletion_protection": deletionProtectionAttribute(),
+			"force_delete":        forceDeleteAttribute("policy", "using it as access or contract policy"),
 			"id": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Policy identifier",
+				Computed:            true,
+				MarkdownDescription: "Policy identifier, generated by the connector when not set. Changing it replaces the policy.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"created_at": createdAtAttribute(),
 		},
 	}
 }
 
+// UpgradeState upgrades the states written before the policy type became a
+// string, and before the consequences and parent permissions of duties
+// became JSON.
+func (p *PoliciesResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: rawStateUpgrader(func(state map[string]interface{}) error {
+			if err := upgradePolicyStateV0(state); err != nil {
+				return err
+			}
+			return upgradePolicyStateV1(state)
+		}),
+		1: rawStateUpgrader(upgradePolicyStateV1),
+	}
+}
+
+// upgradePolicyStateV0 converts the policy type of a version 0 state from its
+// {"@policytype" = "set"} map form to a string.
+func upgradePolicyStateV0(state map[string]interface{}) error {
+	policy, _ := state["policy"].(map[string]interface{})
+	if policy == nil {
+		return nil
+	}
+
+	policyType := string(SetPolicyType)
+	if typeMap, _ := policy["type"].(map[string]interface{}); typeMap != nil {
+		if value, ok := typeMap[legacyPolicyTypeKey].(string); ok && value != "" {
+			policyType = value
+		}
+	}
+
+	for _, valid := range policyTypes {
+		if policyType == valid {
+			policy["type"] = policyType
+			return nil
+		}
+	}
+	return fmt.Errorf("the policy type must be one of %s, got %q", strings.Join(policyTypes, ", "), policyType)
+}
+
+// upgradePolicyStateV1 encodes the consequences and parent permissions of the
+// duties of a version 1 state, nested objects of at most three levels, as JSON.
+func upgradePolicyStateV1(state map[string]interface{}) error {
+	policy, _ := state["policy"].(map[string]interface{})
+	if policy == nil {
+		return nil
+	}
+
+	duties, _ := policy["obligations"].([]interface{})
+	permissions, _ := policy["permissions"].([]interface{})
+	for _, permission := range permissions {
+		if permission, _ := permission.(map[string]interface{}); permission != nil {
+			permissionDuties, _ := permission["duties"].([]interface{})
+			duties = append(duties, permissionDuties...)
+		}
+	}
+
+	for _, duty := range duties {
+		duty, _ := duty.(map[string]interface{})
+		if duty == nil {
+			continue
+		}
+		for _, key := range []string{"consequence", "parent_permission"} {
+			if duty[key] == nil {
+				continue
+			}
+			document, err := json.Marshal(policyStateToJSON(duty[key]))
+			if err != nil {
+				return err
+			}
+			duty[key] = string(document)
+		}
+	}
+	return nil
+}
+
+// policyStateToJSON converts a duty or permission of a version 1 state to the
+// JSON document of the EDC client, dropping the null attributes.
+func policyStateToJSON(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		document := make(map[string]interface{}, len(value))
+		for key, attribute := range value {
+			if attribute == nil {
+				continue
+			}
+			switch key {
+			case "included_in":
+				key = "includedIn"
+			case "parent_permission":
+				key = "parentPermission"
+			}
+			document[key] = policyStateToJSON(attribute)
+		}
+		return document
+	case []interface{}:
+		document := make([]interface{}, len(value))
+		for i, element := range value {
+			document[i] = policyStateToJSON(element)
+		}
+		return document
+	default:
+		return value
+	}
+}
+
 func ConstraintSchema() schema.SingleNestedAttribute {
 	return schema.SingleNestedAttribute{
 		Optional: true,
@@ -151,8 +291,8 @@ func ActionSchema() schema.SingleNestedAttribute {
 	}
 }
 
-func PermissionSchema(level int) map[string]schema.Attribute {
-	attributes := map[string]schema.Attribute{
+func PermissionSchema() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
 		"assignee": schema.StringAttribute{
 			Optional: true,
 		},
@@ -173,21 +313,17 @@ func PermissionSchema(level int) map[string]schema.Attribute {
 		"edctype": schema.StringAttribute{
 			Optional: true,
 		},
-	}
-	if level != 0 {
-		attributes["duties"] = schema.ListNestedAttribute{
+		"duties": schema.ListNestedAttribute{
 			Optional: true,
 			NestedObject: schema.NestedAttributeObject{
-				Attributes: DutySchema(level - 1),
+				Attributes: DutySchema(),
 			},
-		}
+		},
 	}
-
-	return attributes
 }
 
-func DutySchema(level int) map[string]schema.Attribute {
-	attributes := map[string]schema.Attribute{
+func DutySchema() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
 		"assignee": schema.StringAttribute{
 			Optional: true,
 		},
@@ -204,42 +340,53 @@ func DutySchema(level int) map[string]schema.Attribute {
 			Optional:    true,
 			ElementType: ConstraintSchema().GetType(),
 		},
-		"parent_permission": schema.SingleNestedAttribute{
-			Optional:   true,
-			Attributes: PermissionSchema(level),
-		},
-		"action": ActionSchema(),
+		"parent_permission": PolicyNodeJSONSchema("permission", "`edctype` and `duties`", func() interface{} { return &policies.Permission{} }),
+		"action":            ActionSchema(),
+		"consequence":       PolicyNodeJSONSchema("duty", "`consequence` and `parentPermission`", func() interface{} { return &policies.Duty{} }),
 	}
-	if level != 0 {
-		attributes["consequence"] = schema.SingleNestedAttribute{
-			Optional:   true,
-			Attributes: DutySchema(level - 1),
-		}
+}
+
+// PolicyNodeJSONSchema returns the schema of a duty or permission nested in a
+// duty, held as JSON so that duties nest to any depth without growing the
+// schema.
+func PolicyNodeJSONSchema(kind, keys string, newTarget func() interface{}) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:   true,
+		CustomType: jsonStringType{},
+		MarkdownDescription: fmt.Sprintf("The %s as a JSON object, usually set with `jsonencode`, of any depth. ", kind) +
+			"Its keys are the ones of the connector API, in camel case: `assignee`, `assigner`, `target`, `uid`, `constraints`, `action` " +
+			"(with `constraint`, `includedIn` and `type`), " + keys + ".",
+		Validators: []validator.String{
+			jsonDecodeValidator{description: kind, newTarget: newTarget},
+		},
 	}
-	return attributes
 }
 
-func ProhibitionSchema() schema.SingleNestedAttribute {
-	return schema.SingleNestedAttribute{
-		Optional: true,
-		Attributes: map[string]schema.Attribute{
-			"assignee": schema.StringAttribute{
-				Optional: true,
-			},
-			"assigner": schema.StringAttribute{
-				Optional: true,
-			},
-			"target": schema.StringAttribute{
-				Optional: true,
-			},
-			"uid": schema.StringAttribute{
-				Optional: true,
-			},
-			"constraints": schema.ListAttribute{
-				Optional:    true,
-				ElementType: ConstraintSchema().GetType(),
+func ProhibitionSchema() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"assignee": schema.StringAttribute{
+			Optional: true,
+		},
+		"assigner": schema.StringAttribute{
+			Optional: true,
+		},
+		"target": schema.StringAttribute{
+			Optional: true,
+		},
+		"uid": schema.StringAttribute{
+			Optional: true,
+		},
+		"constraints": schema.ListAttribute{
+			Optional:    true,
+			ElementType: ConstraintSchema().GetType(),
+		},
+		"action": ActionSchema(),
+		"remedies": schema.ListNestedAttribute{
+			Optional:            true,
+			MarkdownDescription: "Duties to fulfil when the prohibition is violated.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: DutySchema(),
 			},
-			"action": ActionSchema(),
 		},
 	}
 }
@@ -252,18 +399,15 @@ func PolicySchema() schema.SingleNestedAttribute {
 			"uid": schema.StringAttribute{
 				Optional: true,
 			},
-			"type": schema.MapAttribute{
+			"type": schema.StringAttribute{
 				Optional: true,
 				Computed: true,
-				Default: mapdefault.StaticValue(
-					types.MapValueMust(
-						types.StringType,
-						map[string]attr.Value{
-							"@policytype": types.StringValue("set"),
-						},
-					),
-				),
-				ElementType: types.StringType,
+				Default:  stringdefault.StaticString(string(SetPolicyType)),
+				MarkdownDescription: "Type of the policy, one of `" + strings.Join(policyTypes, "`, `") + "`. Defaults to `set`. " +
+					"The `agreement` type is the ODRL name of the `contract` type, which the connector stores.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(policyTypes...),
+				},
 			},
 			"assignee": schema.StringAttribute{
 				Optional: true,
@@ -288,18 +432,20 @@ func PolicySchema() schema.SingleNestedAttribute {
 			"obligations": schema.ListNestedAttribute{
 				Optional: true,
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: DutySchema(MaxRecursionLevel),
+					Attributes: DutySchema(),
 				},
 			},
 			"permissions": schema.ListNestedAttribute{
 				Optional: true,
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: PermissionSchema(MaxRecursionLevel),
+					Attributes: PermissionSchema(),
 				},
 			},
-			"prohibitions": schema.ListAttribute{
-				Optional:    true,
-				ElementType: ProhibitionSchema().GetType(),
+			"prohibitions": schema.ListNestedAttribute{
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: ProhibitionSchema(),
+				},
 			},
 			"target": schema.StringAttribute{
 				Optional: true,
@@ -310,7 +456,15 @@ func PolicySchema() schema.SingleNestedAttribute {
 
 // PoliciesResource defines the policies resource implementation.
 type PoliciesResource struct {
-	client *policies.Client
+	connectors *EDCConnectors
+}
+
+// ModifyPlan rejects destroying or replacing the policy when it is protected
+// against deletion, and records the planned policy id, which contract
+// definitions checking their references may use.
+func (p *PoliciesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedDeletionProtection(ctx, req, resp, path.Root("connector"), path.Root("id"))
+	p.connectors.recordPlannedId(ctx, req.Plan, policyReference, path.Root("id"))
 }
 
 func (p *PoliciesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -319,26 +473,17 @@ func (p *PoliciesResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
-	cfg, ok := req.ProviderData.(*edc.Config)
+	connectors, ok := req.ProviderData.(*EDCConnectors)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *edc.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *EDCConnectors, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	client, err := policies.New(*cfg)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to initiate policies client",
-			fmt.Sprintf("Client Error: %v", err),
-		)
-		return
-	}
-
-	p.client = client
+	p.connectors = connectors
 }
 
 func (p *PoliciesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -351,18 +496,26 @@ func (p *PoliciesResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	connector, diags := p.connectors.Connector(ctx, data.Connector)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	sdkObject := data.toSDKObject()
 
-	policy, err := p.client.CreatePolicy(*sdkObject)
+	policy, err := connector.Policies.CreatePolicy(*sdkObject)
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create Policy, got error: %s", err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.Plan.Schema, nil, "create Policy", err)
 		return
 	}
 
 	// For the purposes of this Policies code, hardcoding a response value to
 	// save into the Terraform state.
 	data.Id = types.StringValue(policy.Id)
+	data.CreatedAt = types.Int64Value(policy.CreatedAt)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -383,16 +536,29 @@ func (p *PoliciesResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	policy, err := p.client.GetPolicy(data.Id.ValueString())
+	connector, diags := p.connectors.Connector(ctx, data.Connector)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := connector.Policies.GetPolicy(data.Id.ValueString())
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Policy with id %s, got error: %s", data.Id.String(), err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.State.Schema, nil, fmt.Sprintf("read Policy with id %s", data.Id.String()), err)
 		return
 	}
 
 	policy.Policy.UID = data.Policy.UID.ValueStringPointer()
+	priorType := data.Policy.Type
 
 	data.Policy = *toTFPolicy(policy.Policy)
+	data.CreatedAt = types.Int64Value(policy.CreatedAt)
+	// The connector stores agreement policies as contract policies.
+	if priorType.ValueString() == string(AgreementPolicyType) && data.Policy.Type.ValueString() == string(ContractPolicyType) {
+		data.Policy.Type = priorType
+	}
 
 	tflog.Info(ctx, "Policy", map[string]any{
 		"TYPE ": data.Policy.Type,
@@ -421,19 +587,36 @@ func (p *PoliciesResource) Delete(ctx context.Context, req resource.DeleteReques
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
+	if resp.Diagnostics.HasError() || !checkDeletionProtection(data.DeletionProtection, &resp.Diagnostics) {
+		return
+	}
+
+	connector, diags := p.connectors.Connector(ctx, data.Connector)
+	resp.Diagnostics.Append(diags...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	err := p.client.DeletePolicy(data.Id.ValueString())
+	definitions, err := policyDeletionBlockers(connector, data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Check Policy References",
+			"The provider could not look up the contract definitions referencing the policy, which is deleted regardless.\n\nError: "+err.Error(),
+		)
+	} else if !clearDeletionBlockers(ctx, connector, "policy", data.Id.ValueString(), definitions, nil, data.ForceDelete.ValueBool(), &resp.Diagnostics) {
+		return
+	}
+
+	err = connector.Policies.DeletePolicy(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete Policy with id %s, got error: %s", data.Id.String(), err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.State.Schema, nil, fmt.Sprintf("delete Policy with id %s", data.Id.String()), err)
 		return
 	}
 }
 
 func (p *PoliciesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	p.connectors.ImportStatePassthroughID(ctx, req, resp)
 }
 
 func (c *Constraint) toSDKObject() *policies.Constraint {
@@ -443,6 +626,9 @@ func (c *Constraint) toSDKObject() *policies.Constraint {
 }
 
 func (a *Action) toSDKObject() *policies.Action {
+	if a == nil {
+		return nil
+	}
 	action := &policies.Action{
 		IncludedIn: a.IncludedIn.ValueStringPointer(),
 		ActionType: a.ActionType.ValueStringPointer(),
@@ -498,13 +684,25 @@ func (d *Duty) toSDKObject() *policies.Duty {
 		}
 	}
 	duty := &policies.Duty{
-		Assignee:         d.Assignee.ValueStringPointer(),
-		Assigner:         d.Assigner.ValueStringPointer(),
-		Target:           d.Target.ValueStringPointer(),
-		UID:              d.UID.ValueStringPointer(),
-		Consequence:      d.Consequence.toSDKObject(), //nolint:all
-		Action:           d.Action.toSDKObject(),
-		ParentPermission: d.ParentPermission.toSDKObject(),
+		Assignee: d.Assignee.ValueStringPointer(),
+		Assigner: d.Assigner.ValueStringPointer(),
+		Target:   d.Target.ValueStringPointer(),
+		UID:      d.UID.ValueStringPointer(),
+		Action:   d.Action.toSDKObject(),
+	}
+
+	// The validators of the schema reject the documents which do not decode.
+	if !d.Consequence.IsNull() && !d.Consequence.IsUnknown() {
+		var consequence policies.Duty
+		if d.Consequence.Unmarshal(&consequence) == nil {
+			duty.Consequence = &consequence
+		}
+	}
+	if !d.ParentPermission.IsNull() && !d.ParentPermission.IsUnknown() {
+		var parentPermission policies.Permission
+		if d.ParentPermission.Unmarshal(&parentPermission) == nil {
+			duty.ParentPermission = &parentPermission
+		}
 	}
 
 	if len(constraints) != 0 {
@@ -514,33 +712,47 @@ func (d *Duty) toSDKObject() *policies.Duty {
 	return duty
 }
 
-func (d *Prohibition) toSDKObject() *policies.Prohibition {
+func (d *Prohibition) toSDKObject() *legacyProhibition {
 	var constraints []policies.Constraint
 	if d.Constraints != nil {
 		for _, v := range *d.Constraints {
 			constraints = append(constraints, *v.toSDKObject())
 		}
 	}
-	prohibition := &policies.Prohibition{
-		Assignee: d.Assignee.ValueStringPointer(),
-		Assigner: d.Assigner.ValueStringPointer(),
-		Target:   d.Target.ValueStringPointer(),
-		UID:      d.UID.ValueStringPointer(),
-		Action:   d.Action.toSDKObject(),
+
+	var remedies []policies.Duty
+	if d.Remedies != nil {
+		for _, v := range *d.Remedies {
+			remedies = append(remedies, *v.toSDKObject())
+		}
+	}
+
+	prohibition := &legacyProhibition{
+		Prohibition: policies.Prohibition{
+			Assignee: d.Assignee.ValueStringPointer(),
+			Assigner: d.Assigner.ValueStringPointer(),
+			Target:   d.Target.ValueStringPointer(),
+			UID:      d.UID.ValueStringPointer(),
+			Action:   d.Action.toSDKObject(),
+		},
 	}
 
 	if len(constraints) != 0 {
 		prohibition.Constraints = &constraints
 	}
 
+	if len(remedies) != 0 {
+		prohibition.Remedies = &remedies
+	}
+
 	return prohibition
 }
 
-func (p *PolicyResourceModel) toSDKObject() *policies.CreatePolicyInput {
+func (p *PolicyResourceModel) toSDKObject() *policyInput {
 
 	var extensibleProperties policies.ExtensibleProperties
 	var obligations []policies.Duty
-	var prohibitions []policies.Prohibition
+	var prohibitions []legacyProhibition
 	var permissions []policies.Permission
 
 	if p.Policy.ExtensibleProperties != nil {
@@ -568,12 +780,19 @@ func (p *PolicyResourceModel) toSDKObject() *policies.CreatePolicyInput {
 		}
 	}
 
-	policy := policies.Policy{
-		UID:          p.Policy.UID.ValueStringPointer(),
-		Assignee:     p.Policy.Assignee.ValueStringPointer(),
-		Assigner:     p.Policy.Assigner.ValueStringPointer(),
-		InheritsFrom: p.Policy.InheritsFrom.ValueStringPointer(),
-		Target:       p.Policy.Target.ValueStringPointer(),
+	policy := legacyPolicy{
+		Policy: policies.Policy{
+			UID:          p.Policy.UID.ValueStringPointer(),
+			Assignee:     p.Policy.Assignee.ValueStringPointer(),
+			Assigner:     p.Policy.Assigner.ValueStringPointer(),
+			InheritsFrom: p.Policy.InheritsFrom.ValueStringPointer(),
+			Target:       p.Policy.Target.ValueStringPointer(),
+		},
+	}
+	if !p.Policy.Type.IsNull() && !p.Policy.Type.IsUnknown() {
+		policy.Type = map[string]policies.PolicyType{
+			legacyPolicyTypeKey: PolicyType(p.Policy.Type.ValueString()).sdkType(),
+		}
 	}
 	if len(extensibleProperties) != 0 {
 		policy.ExtensibleProperties = &extensibleProperties
@@ -591,8 +810,10 @@ func (p *PolicyResourceModel) toSDKObject() *policies.CreatePolicyInput {
 		policy.Permissions = &permissions
 	}
 
-	return &policies.CreatePolicyInput{
-		Id:     p.Id.ValueStringPointer(),
-		Policy: policy,
+	input := &policyInput{Policy: policy}
+	// The connector generates the id when it is not set.
+	if !p.Id.IsUnknown() && p.Id.ValueString() != "" {
+		input.Id = p.Id.ValueStringPointer()
 	}
+	return input
 }
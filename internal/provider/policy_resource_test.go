@@ -1,11 +1,17 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/Think-iT-Labs/edc-connector-client-go/service/policies"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccPolicyResource(t *testing.T) {
@@ -23,6 +29,39 @@ func TestAccPolicyResource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "id", policyId),
 					resource.TestCheckResourceAttr(resourceName, "policy.uid", policyUID),
+					resource.TestCheckResourceAttr(resourceName, "policy.type", "offer"),
+					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPolicyResource_generatedId(t *testing.T) {
+	resourceName := "edc_policy.generated"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "edc_policy" "generated" {
+	policy = {
+		permissions = [
+			{
+				action = {
+					type = "USE"
+				},
+				edctype = "dataspaceconnector:permission"
+			}
+		]
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
 				),
 			},
 		},
@@ -35,6 +74,7 @@ resource "edc_policy" "pol" {
 	id = %[1]q
 	policy = {
 		uid = %[2]q,
+		type = "offer"
 		permissions = [
 			{
 				target = "assetId",
@@ -48,3 +88,160 @@ resource "edc_policy" "pol" {
 }
 `, policyId, policyUID)
 }
+
+func TestPoliciesResource_UpgradeState(t *testing.T) {
+	tests := []struct {
+		name          string
+		version       int64
+		state         string
+		expected      string
+		expectedError bool
+	}{
+		{
+			name:     "policy type",
+			state:    `{"id": "policy-1", "policy": {"uid": "uid", "type": {"@policytype": "contract"}}}`,
+			expected: `{"id": "policy-1", "policy": {"uid": "uid", "type": "contract"}}`,
+		},
+		{
+			name:     "default policy type",
+			state:    `{"id": "policy-1", "policy": {"type": null}}`,
+			expected: `{"id": "policy-1", "policy": {"type": "set"}}`,
+		},
+		{
+			name:     "no policy",
+			state:    `{"id": "policy-1", "policy": null}`,
+			expected: `{"id": "policy-1", "policy": null}`,
+		},
+		{
+			name:          "invalid policy type",
+			state:         `{"id": "policy-1", "policy": {"type": {"@policytype": "other"}}}`,
+			expectedError: true,
+		},
+		{
+			name:     "version 0 duties",
+			state:    `{"id": "policy-1", "policy": {"type": null, "obligations": [{"uid": "duty-1", "consequence": {"uid": "duty-2", "consequence": null}}]}}`,
+			expected: `{"id": "policy-1", "policy": {"type": "set", "obligations": [{"uid": "duty-1", "consequence": "{\"uid\":\"duty-2\"}"}]}}`,
+		},
+		{
+			name:    "consequences",
+			version: 1,
+			state: `{"id": "policy-1", "policy": {"type": "set", "obligations": [{"uid": "duty-1", "parent_permission": null, "consequence": {
+				"uid": "duty-2", "action": {"type": "USE", "included_in": null, "constraint": null},
+				"consequence": {"uid": "duty-3", "constraints": [{"edctype": "AtomicConstraint"}]}
+			}}]}}`,
+			expected: `{"id": "policy-1", "policy": {"type": "set", "obligations": [{"uid": "duty-1", "parent_permission": null,
+				"consequence": "{\"action\":{\"type\":\"USE\"},\"consequence\":{\"constraints\":[{\"edctype\":\"AtomicConstraint\"}],\"uid\":\"duty-3\"},\"uid\":\"duty-2\"}"
+			}]}}`,
+		},
+		{
+			name:    "parent permissions",
+			version: 1,
+			state: `{"id": "policy-1", "policy": {"type": "set", "permissions": [{"uid": "permission-1", "duties": [{"uid": "duty-1", "consequence": null, "parent_permission": {
+				"uid": "permission-1", "action": {"type": "USE", "included_in": "USAGE"}, "duties": [{"uid": "duty-1", "parent_permission": null}]
+			}}]}]}}`,
+			expected: `{"id": "policy-1", "policy": {"type": "set", "permissions": [{"uid": "permission-1", "duties": [{"uid": "duty-1", "consequence": null,
+				"parent_permission": "{\"action\":{\"includedIn\":\"USAGE\",\"type\":\"USE\"},\"duties\":[{\"uid\":\"duty-1\"}],\"uid\":\"permission-1\"}"
+			}]}]}}`,
+		},
+		{
+			name:     "version 1 no policy",
+			version:  1,
+			state:    `{"id": "policy-1", "policy": null}`,
+			expected: `{"id": "policy-1", "policy": null}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upgrader := (&PoliciesResource{}).UpgradeState(context.Background())[tt.version]
+			resp := &fwresource.UpgradeStateResponse{}
+			upgrader.StateUpgrader(context.Background(), fwresource.UpgradeStateRequest{
+				RawState: &tfprotov6.RawState{JSON: []byte(tt.state)},
+			}, resp)
+
+			assert.Equal(t, tt.expectedError, resp.Diagnostics.HasError(), resp.Diagnostics)
+			if !tt.expectedError {
+				assert.JSONEq(t, tt.expected, string(resp.DynamicValue.JSON))
+			}
+		})
+	}
+}
+
+func TestPolicyResourceModel_toSDKObject_type(t *testing.T) {
+	tests := []struct {
+		policyType types.String
+		expected   map[string]policies.PolicyType
+	}{
+		{policyType: types.StringValue("set"), expected: map[string]policies.PolicyType{"@policytype": policies.SetPolicyType}},
+		{policyType: types.StringValue("offer"), expected: map[string]policies.PolicyType{"@policytype": policies.OfferPolicyType}},
+		{policyType: types.StringValue("contract"), expected: map[string]policies.PolicyType{"@policytype": policies.ContractPolicyType}},
+		{policyType: types.StringValue("agreement"), expected: map[string]policies.PolicyType{"@policytype": policies.ContractPolicyType}},
+		{policyType: types.StringNull()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policyType.String(), func(t *testing.T) {
+			model := PolicyResourceModel{Policy: Policy{Type: tt.policyType}}
+			assert.Equal(t, tt.expected, model.toSDKObject().Policy.Type)
+		})
+	}
+
+	assert.Equal(t, types.StringValue("set"), toTFPolicyType(nil))
+	assert.Equal(t, types.StringValue("offer"), toTFPolicyType(map[string]policies.PolicyType{"@policytype": policies.OfferPolicyType}))
+}
+
+func TestDuty_toSDKObject_consequences(t *testing.T) {
+	consequence := `{"uid": "duty-2", "consequence": {"uid": "duty-3", "consequence": {"uid": "duty-4", "consequence": {
+		"uid": "duty-5", "action": {"type": "DELETE"}, "parentPermission": {"uid": "permission-1", "duties": [{"uid": "duty-6"}]}
+	}}}}`
+	duty := Duty{
+		UID:              types.StringValue("duty-1"),
+		Consequence:      jsonStringValue{StringValue: types.StringValue(consequence)},
+		ParentPermission: jsonStringValue{StringValue: types.StringNull()},
+	}
+
+	sdkDuty := duty.toSDKObject()
+	assert.Nil(t, sdkDuty.ParentPermission)
+	depth := 0
+	for next := sdkDuty.Consequence; next != nil; next = next.Consequence {
+		depth++
+		if next.Consequence == nil {
+			assert.Equal(t, "DELETE", *next.Action.ActionType)
+			assert.Equal(t, "duty-6", *(*next.ParentPermission.Duties)[0].UID)
+		}
+	}
+	assert.Equal(t, 4, depth)
+
+	tfDuty := toTFDuty(*sdkDuty)
+	assert.Equal(t, types.StringValue("duty-1"), tfDuty.UID)
+	assert.True(t, tfDuty.ParentPermission.IsNull())
+	equal, diags := duty.Consequence.StringSemanticEquals(context.Background(), tfDuty.Consequence)
+	assert.False(t, diags.HasError(), diags)
+	assert.True(t, equal, tfDuty.Consequence.ValueString())
+}
+
+func TestProhibition_toSDKObject_remedies(t *testing.T) {
+	prohibition := Prohibition{
+		Action: &Action{ActionType: types.StringValue("DISTRIBUTE")},
+		Remedies: &[]Duty{
+			{UID: types.StringValue("compensate"), Action: &Action{ActionType: types.StringValue("COMPENSATE")}},
+		},
+	}
+
+	sdkProhibition := prohibition.toSDKObject()
+	assert.Len(t, *sdkProhibition.Remedies, 1)
+	assert.Equal(t, "compensate", *(*sdkProhibition.Remedies)[0].UID)
+
+	tfProhibition := toTFProhibition(*sdkProhibition)
+	assert.Equal(t, types.StringValue("DISTRIBUTE"), tfProhibition.Action.ActionType)
+	assert.Equal(t, types.StringValue("compensate"), (*tfProhibition.Remedies)[0].UID)
+	assert.Equal(t, types.StringValue("COMPENSATE"), (*tfProhibition.Remedies)[0].Action.ActionType)
+}
+
+func TestPolicyResourceModel_toSDKObject_id(t *testing.T) {
+	model := PolicyResourceModel{Id: types.StringValue("policy-1")}
+	assert.Equal(t, "policy-1", *model.toSDKObject().Id)
+
+	for _, id := range []types.String{types.StringUnknown(), types.StringNull(), types.StringValue("")} {
+		model.Id = id
+		assert.Nil(t, model.toSDKObject().Id, id.String())
+	}
+}
@@ -5,15 +5,19 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strconv"
 	"strings"
 
-	"github.com/Think-iT-Labs/edc-connector-client-go/config"
 	"github.com/Think-iT-Labs/edc-connector-client-go/edc"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -30,8 +34,32 @@ type EDCProvider struct {
 
 // EDCProviderModel describes the provider data model.
 type EDCProviderModel struct {
-	Token     types.String `tfsdk:"token"`
-	Addresses Addresses    `tfsdk:"addresses"`
+	Token                types.String              `tfsdk:"token"`
+	TokenFile            types.String              `tfsdk:"token_file"`
+	TokenCommand         types.List                `tfsdk:"token_command"`
+	TokenEnv             types.String              `tfsdk:"token_env"`
+	ManagementAPIVersion types.String              `tfsdk:"management_api_version"`
+	Context              types.Map                 `tfsdk:"context"`
+	Addresses            *Addresses                `tfsdk:"addresses"`
+	ConnectorConfigFile  types.String              `tfsdk:"connector_config_file"`
+	ConnectorHost        types.String              `tfsdk:"connector_host"`
+	ConnectorPortOffset  types.Int64               `tfsdk:"connector_port_offset"`
+	Connectors           map[string]ConnectorModel `tfsdk:"connectors"`
+	HealthCheck          types.Bool                `tfsdk:"health_check"`
+}
+
+// ConnectorModel describes the configuration of a single connector.
+type ConnectorModel struct {
+	Token                types.String `tfsdk:"token"`
+	TokenFile            types.String `tfsdk:"token_file"`
+	TokenCommand         types.List   `tfsdk:"token_command"`
+	TokenEnv             types.String `tfsdk:"token_env"`
+	ManagementAPIVersion types.String `tfsdk:"management_api_version"`
+	Context              types.Map    `tfsdk:"context"`
+	Addresses            *Addresses   `tfsdk:"addresses"`
+	ConnectorConfigFile  types.String `tfsdk:"connector_config_file"`
+	ConnectorHost        types.String `tfsdk:"connector_host"`
+	ConnectorPortOffset  types.Int64  `tfsdk:"connector_port_offset"`
 }
 
 type Addresses struct {
@@ -48,33 +76,138 @@ func (p *EDCProvider) Metadata(ctx context.Context, req provider.MetadataRequest
 }
 
 func (p *EDCProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	attributes := connectorSchemaAttributes(true)
+	attributes["connectors"] = schema.MapNestedAttribute{
+		Optional: true,
+		MarkdownDescription: "Additional connectors managed by the provider, by name. " +
+			"Resources and data sources select one of them with their `connector` attribute. " +
+			"Named connectors do not read the `EDC_*` environment variables.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: connectorSchemaAttributes(false),
+		},
+	}
+	attributes["health_check"] = schema.BoolAttribute{
+		Optional: true,
+		MarkdownDescription: "Whether to check that every connector is live and ready through the observability API served on its `default` address, " +
+			"and detect the version of its management API, when the provider is configured. Defaults to `false`, in which case the connectors " +
+			"are only reached by the resources and data sources. Can also be set with the `EDC_HEALTH_CHECK` environment variable.",
+	}
+
 	resp.Schema = schema.Schema{
-		Attributes: map[string]schema.Attribute{
-			"token": schema.StringAttribute{
-				Optional: true,
+		Attributes: attributes,
+	}
+}
+
+// connectorSchemaAttributes returns the attributes configuring a connector.
+// Their descriptions mention the environment variables when useEnv is set.
+func connectorSchemaAttributes(useEnv bool) map[string]schema.Attribute {
+	envHint := func(name string) string {
+		if !useEnv {
+			return ""
+		}
+		return " Can also be set with the `" + name + "` environment variable."
+	}
+	overridingValues := "Values set in `token` and `addresses`"
+	if useEnv {
+		overridingValues = "Values set in the `EDC_*` environment variables, then in `token` and `addresses`,"
+	}
+
+	return map[string]schema.Attribute{
+		"token": schema.StringAttribute{
+			Optional: true,
+		},
+		"token_file": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Path to a file holding the token." + envHint("EDC_TOKEN_FILE"),
+			Validators: []validator.String{
+				stringvalidator.ConflictsWith(tokenAttributesExcept("token_file")...),
+			},
+		},
+		"token_command": schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			MarkdownDescription: "Command, with its arguments, printing the token. " +
+				"The command prints either the token alone, or a JSON object with a `token` field and an optional `expires_at` RFC 3339 timestamp or `expires_in` number of seconds. " +
+				"Without explicit expiry, the `exp` claim of JWT tokens is used. The command runs again once the token expires.",
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+				listvalidator.ConflictsWith(tokenAttributesExcept("token_command")...),
 			},
-			"addresses": schema.SingleNestedAttribute{
-				Optional: true,
-				Attributes: map[string]schema.Attribute{
-					"control": schema.StringAttribute{
-						Optional: true,
-					},
-					"management": schema.StringAttribute{
-						Optional: true,
-					},
-					"protocol": schema.StringAttribute{
-						Optional: true,
-					},
-					"public": schema.StringAttribute{
-						Optional: true,
-					},
-					"default": schema.StringAttribute{
-						Optional: true,
-					},
+		},
+		"token_env": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Name of the environment variable holding the token.",
+			Validators: []validator.String{
+				stringvalidator.ConflictsWith(tokenAttributesExcept("token_env")...),
+			},
+		},
+		"addresses": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{
+				"control": schema.StringAttribute{
+					Optional: true,
+				},
+				"management": schema.StringAttribute{
+					Optional: true,
+				},
+				"protocol": schema.StringAttribute{
+					Optional: true,
+				},
+				"public": schema.StringAttribute{
+					Optional: true,
+				},
+				"default": schema.StringAttribute{
+					Optional: true,
 				},
 			},
 		},
+		"management_api_version": schema.StringAttribute{
+			Optional: true,
+			MarkdownDescription: "Version of the management API served at the `management` address: " +
+				"`v1` for the legacy API of milestone releases, `v2` or `v3` for the JSON-LD based APIs, which are called under the `/v2` and `/v3` paths of the `management` address. " +
+				"With `auto`, the default, the version is detected on first use. " +
+				"Resources keep the v1 representation of assets, policies and contract definitions with every version, " +
+				"asset properties prefixed by `asset:prop:` being translated to the EDC namespace." + envHint("EDC_MANAGEMENT_API_VERSION"),
+			Validators: []validator.String{
+				stringvalidator.OneOf(managementAPIVersions...),
+			},
+		},
+		"context": schema.MapAttribute{
+			Optional:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: contextDescription + " Resources may define additional prefixes with their own `context` attribute.",
+			Validators:          contextValidators(),
+		},
+		"connector_config_file": schema.StringAttribute{
+			Optional: true,
+			MarkdownDescription: "Path to the `configuration.properties` file of the EDC connector. " +
+				"The addresses are derived from the `web.http.*.port` and `web.http.*.path` properties " +
+				"and the token from the `edc.api.auth.key` or `edc.api.control.auth.apikey.value` property. " +
+				overridingValues + " take precedence." + envHint("EDC_CONNECTOR_CONFIG_FILE"),
+		},
+		"connector_host": schema.StringAttribute{
+			Optional: true,
+			MarkdownDescription: "Scheme and host serving the connector described by `connector_config_file`. " +
+				"Defaults to `" + defaultConnectorHost + "`." + envHint("EDC_CONNECTOR_HOST"),
+		},
+		"connector_port_offset": schema.Int64Attribute{
+			Optional: true,
+			MarkdownDescription: "Offset added to the ports read from `connector_config_file`, when the connector ports are published on other host ports, " +
+				"such as `20000` for the provider connector of the docker-compose setup. Defaults to `0`." + envHint("EDC_CONNECTOR_PORT_OFFSET"),
+		},
+	}
+}
+
+// tokenAttributesExcept returns the paths of the attributes setting the token
+// of a connector, relative to one of them, except the given one.
+func tokenAttributesExcept(name string) []path.Expression {
+	var expressions []path.Expression
+	for _, attribute := range []string{"token", "token_file", "token_command", "token_env"} {
+		if attribute != name {
+			expressions = append(expressions, path.MatchRelative().AtParent().AtName(attribute))
+		}
 	}
+	return expressions
 }
 
 func (p *EDCProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
@@ -86,123 +219,383 @@ func (p *EDCProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		return
 	}
 
-	token, edcAddresses := validateProviderOptions(data, resp)
+	connectors := &EDCConnectors{
+		named: make(map[string]*managedConnector, len(data.Connectors)),
+	}
+
+	if len(data.Connectors) == 0 || data.connector().isSet() {
+		token, edcAddresses := validateProviderOptions(data, resp)
+		apiVersion := validateManagementAPIVersion(data.connector(), path.Empty(), true, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		connectors.defaultConnector = newConfiguredConnector(ctx, data.connector(), path.Empty(), token, edcAddresses, apiVersion, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	for name, connectorData := range data.Connectors {
+		root := path.Root("connectors").AtMapKey(name)
+		token, edcAddresses := validateConnectorOptions(connectorData, root, false, &resp.Diagnostics)
+		apiVersion := validateManagementAPIVersion(connectorData, root, false, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		connectors.named[name] = newConfiguredConnector(ctx, connectorData, root, token, edcAddresses, apiVersion, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if healthCheckEnabled(data, &resp.Diagnostics) {
+		connectors.checkHealth(ctx, &resp.Diagnostics)
+	}
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	cfg, err := config.LoadConfig(
-		token,
-		edcAddresses,
-	)
+	resp.DataSourceData = connectors
+	resp.ResourceData = connectors
+}
+
+// healthCheckEnabled reads the health_check setting, which defaults to false.
+func healthCheckEnabled(data EDCProviderModel, diags *diag.Diagnostics) bool {
+	if data.HealthCheck.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("health_check"),
+			"Unknown EDC Health Check Setting",
+			"The provider cannot tell whether to check the health of the connectors as there is an unknown configuration value for health_check. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the EDC_HEALTH_CHECK environment variable.",
+		)
+		return false
+	}
+
+	if !data.HealthCheck.IsNull() {
+		return data.HealthCheck.ValueBool()
+	}
+
+	value := os.Getenv("EDC_HEALTH_CHECK")
+	if value == "" {
+		return false
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("health_check"),
+			"Invalid EDC_HEALTH_CHECK Environment Variable",
+			fmt.Sprintf("The EDC_HEALTH_CHECK environment variable must be a boolean, got %q.", value),
+		)
+		return false
+	}
+	return enabled
+}
+
+// newConfiguredConnector creates the client set of a connector, using the
+// token printed by its token command when one is configured, and the JSON-LD
+// prefixes of its context.
+func newConfiguredConnector(ctx context.Context, data ConnectorModel, root path.Path, token string, addresses edc.Addresses, apiVersion string, diags *diag.Diagnostics) *managedConnector {
+	prefixes := validateContext(ctx, data, root, diags)
+
+	if diags.HasError() {
+		return nil
+	}
+
+	source := newStaticTokenSource(token)
+
+	if !data.TokenCommand.IsNull() {
+		var command []string
+		diags.Append(data.TokenCommand.ElementsAs(ctx, &command, false)...)
+
+		if diags.HasError() {
+			return nil
+		}
+
+		var err error
+		source, err = newCommandTokenSource(ctx, command)
+		if err != nil {
+			diags.AddAttributeError(
+				root.AtName("token_command"),
+				"Unable to Obtain EDC Token",
+				"The provider cannot create the EDC API client as the token command failed.\n\n"+
+					"Error: "+err.Error(),
+			)
+			return nil
+		}
+	}
 
+	connector, err := newManagedConnector(source, addresses, apiVersion)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diags.AddAttributeError(
+			root,
 			"Unable to Create EDC API Client",
 			"An unexpected error occurred when creating the EDC API client. "+
 				"If the error is not clear, please contact the provider developers.\n\n"+
 				"EDC Client Error: "+err.Error(),
 		)
-		return
+		return nil
 	}
+	connector.setPrefixes(prefixes)
+
+	return connector
+}
 
-	resp.DataSourceData = cfg
-	resp.ResourceData = cfg
+// connector returns the configuration of the connector defined at the
+// provider level.
+func (m EDCProviderModel) connector() ConnectorModel {
+	return ConnectorModel{
+		Token:                m.Token,
+		TokenFile:            m.TokenFile,
+		TokenCommand:         m.TokenCommand,
+		TokenEnv:             m.TokenEnv,
+		ManagementAPIVersion: m.ManagementAPIVersion,
+		Context:              m.Context,
+		Addresses:            m.Addresses,
+		ConnectorConfigFile:  m.ConnectorConfigFile,
+		ConnectorHost:        m.ConnectorHost,
+		ConnectorPortOffset:  m.ConnectorPortOffset,
+	}
+}
+
+// isSet reports whether any connection setting is present in the
+// configuration.
+func (m ConnectorModel) isSet() bool {
+	return !m.Token.IsNull() || !m.TokenFile.IsNull() || !m.TokenCommand.IsNull() || !m.TokenEnv.IsNull() ||
+		m.Addresses != nil || !m.ConnectorConfigFile.IsNull() || !m.ManagementAPIVersion.IsNull() || !m.Context.IsNull()
 }
 
 func validateProviderOptions(data EDCProviderModel, resp *provider.ConfigureResponse) (string, edc.Addresses) {
+	return validateConnectorOptions(data.connector(), path.Empty(), true, &resp.Diagnostics)
+}
+
+// validateConnectorOptions resolves the token and addresses of a connector.
+// Diagnostics are reported relative to the root path of its configuration.
+// The EDC_* environment variables are only read when useEnv is set.
+func validateConnectorOptions(data ConnectorModel, root path.Path, useEnv bool, diags *diag.Diagnostics) (string, edc.Addresses) {
+	getenv := os.Getenv
+	if !useEnv {
+		getenv = func(string) string { return "" }
+	}
+
+	addresses := Addresses{}
+	if data.Addresses != nil {
+		addresses = *data.Addresses
+	}
+
 	if data.Token.IsUnknown() {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("Token"),
+		diags.AddAttributeError(
+			root.AtName("Token"),
 			"Unknown EDC API Token",
 			"The provider cannot create the EDC API client as there is an unknown configuration value for the EDC API token. "+
 				"Either target apply the source of the value first, set the value statically in the configuration, or use the EDC_TOKEN environment variable.",
 		)
 	}
 
-	if data.Addresses.ControlEndpoint.IsUnknown() {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("Control"),
+	for _, source := range []struct {
+		name    string
+		unknown bool
+	}{
+		{"token_file", data.TokenFile.IsUnknown()},
+		{"token_command", data.TokenCommand.IsUnknown()},
+		{"token_env", data.TokenEnv.IsUnknown()},
+	} {
+		if source.unknown {
+			diags.AddAttributeError(
+				root.AtName(source.name),
+				"Unknown EDC API Token Source",
+				fmt.Sprintf("The provider cannot create the EDC API client as there is an unknown configuration value for %s. ", source.name)+
+					"Either target apply the source of the value first, or set the value statically in the configuration.",
+			)
+		}
+	}
+
+	if addresses.ControlEndpoint.IsUnknown() {
+		diags.AddAttributeError(
+			root.AtName("Control"),
 			"Unknown EDC Control Endpoint",
 			"The provider cannot create the EDC Control Endpoint client as there is an unknown configuration value for the EDC Control Endpoint."+
 				"Either target apply the source of the value first, set the value statically in the configuration, or use the EDC_TOKEN environment variable.",
 		)
 	}
 
-	if data.Addresses.DefaultEndpoint.IsUnknown() {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("Default"),
+	if addresses.DefaultEndpoint.IsUnknown() {
+		diags.AddAttributeError(
+			root.AtName("Default"),
 			"Unknown EDC Default Endpoint",
 			"The provider cannot create the EDC Default Endpoint client as there is an unknown configuration value for the EDC Default Endpoint."+
 				"Either target apply the source of the value first, set the value statically in the configuration, or use the EDC_ENDPOINT environment.",
 		)
 	}
 
-	if data.Addresses.ManagementEndpoint.IsUnknown() {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("Management"),
+	if addresses.ManagementEndpoint.IsUnknown() {
+		diags.AddAttributeError(
+			root.AtName("Management"),
 			"Unknown EDC Management Endpoint",
 			"The provider cannot create the EDC Management Endpoint client as there is an unknown configuration value for the EDC Management Endpoint."+
 				"Either target apply the source of the value first, set the value statically in the configuration, or use the EDC_MANAGEMENT environment.",
 		)
 	}
 
-	if data.Addresses.PublicEndpoint.IsUnknown() {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("Public"),
+	if addresses.PublicEndpoint.IsUnknown() {
+		diags.AddAttributeError(
+			root.AtName("Public"),
 			"Unknown EDC Public Endpoint",
 			"The provider cannot create the EDC Public Endpoint client as there is an unknown configuration value for the EDC Public Endpoint."+
 				"Either target apply the source of the value first, set the value statically in the configuration, or use the EDC_PUBLIC environment.",
 		)
 	}
 
-	if data.Addresses.ProtocolEndpoint.IsUnknown() {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("Protocol"),
+	if addresses.ProtocolEndpoint.IsUnknown() {
+		diags.AddAttributeError(
+			root.AtName("Protocol"),
 			"Unknown EDC Protocol Endpoint",
 			"The provider cannot create the EDC Protocol Endpoint client as there is an unknown configuration value for the EDC Protocol Endpoint."+
 				"Either target apply the source of the value first, set the value statically in the configuration, or use the EDC_PROTOCOL environment.",
 		)
 	}
 
-	token := os.Getenv("EDC_TOKEN")
-	controlAddress := os.Getenv("EDC_CONTROL")
-	publicAddress := os.Getenv("EDC_PUBLIC")
-	protocolAddress := os.Getenv("EDC_PROTOCOL")
-	managementAddress := os.Getenv("EDC_MANAGEMENT")
-	defaultAddress := os.Getenv("EDC_DEFAULT")
+	if data.ConnectorConfigFile.IsUnknown() {
+		diags.AddAttributeError(
+			root.AtName("connector_config_file"),
+			"Unknown EDC Connector Configuration File",
+			"The provider cannot read the EDC connector configuration file as there is an unknown configuration value for its path. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the EDC_CONNECTOR_CONFIG_FILE environment variable.",
+		)
+	}
+
+	if data.ConnectorHost.IsUnknown() {
+		diags.AddAttributeError(
+			root.AtName("connector_host"),
+			"Unknown EDC Connector Host",
+			"The provider cannot derive the EDC addresses from the connector configuration file as there is an unknown configuration value for the connector host. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the EDC_CONNECTOR_HOST environment variable.",
+		)
+	}
+
+	if data.ConnectorPortOffset.IsUnknown() {
+		diags.AddAttributeError(
+			root.AtName("connector_port_offset"),
+			"Unknown EDC Connector Port Offset",
+			"The provider cannot derive the EDC addresses from the connector configuration file as there is an unknown configuration value for the connector port offset. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the EDC_CONNECTOR_PORT_OFFSET environment variable.",
+		)
+	}
+
+	var token, controlAddress, publicAddress, protocolAddress, managementAddress, defaultAddress string
+
+	// The connector configuration file is applied first, then the EDC_*
+	// environment variables, then the attributes.
+	connectorConfigFile := getenv("EDC_CONNECTOR_CONFIG_FILE")
+	connectorHost := getenv("EDC_CONNECTOR_HOST")
+	var connectorPortOffset int64
+
+	if !data.ConnectorConfigFile.IsNull() {
+		connectorConfigFile = data.ConnectorConfigFile.ValueString()
+	}
+
+	if !data.ConnectorHost.IsNull() {
+		connectorHost = data.ConnectorHost.ValueString()
+	}
+
+	if !data.ConnectorPortOffset.IsNull() {
+		connectorPortOffset = data.ConnectorPortOffset.ValueInt64()
+	} else if value := getenv("EDC_CONNECTOR_PORT_OFFSET"); value != "" {
+		offset, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			diags.AddAttributeError(
+				root.AtName("connector_port_offset"),
+				"Invalid EDC_CONNECTOR_PORT_OFFSET Environment Variable",
+				fmt.Sprintf("The EDC_CONNECTOR_PORT_OFFSET environment variable must be an integer, got %q.", value),
+			)
+		}
+		connectorPortOffset = offset
+	}
+
+	if connectorConfigFile != "" {
+		connectorConfig, err := loadConnectorConfigFile(connectorConfigFile, connectorHost, connectorPortOffset)
+		if err != nil {
+			diags.AddAttributeError(
+				root.AtName("connector_config_file"),
+				"Invalid EDC Connector Configuration File",
+				"The provider cannot read the EDC connector configuration file: "+err.Error(),
+			)
+		} else {
+			token = connectorConfig.Token
+			controlAddress = connectorConfig.ControlEndpoint
+			publicAddress = connectorConfig.PublicEndpoint
+			protocolAddress = connectorConfig.ProtocolEndpoint
+			managementAddress = connectorConfig.ManagementEndpoint
+			defaultAddress = connectorConfig.DefaultEndpoint
+		}
+	}
+
+	overrideIfSet(&token, getenv("EDC_TOKEN"))
+	overrideIfSet(&controlAddress, getenv("EDC_CONTROL"))
+	overrideIfSet(&publicAddress, getenv("EDC_PUBLIC"))
+	overrideIfSet(&protocolAddress, getenv("EDC_PROTOCOL"))
+	overrideIfSet(&managementAddress, getenv("EDC_MANAGEMENT"))
+	overrideIfSet(&defaultAddress, getenv("EDC_DEFAULT"))
+
+	tokenFile := getenv("EDC_TOKEN_FILE")
+	if !data.TokenFile.IsNull() {
+		tokenFile = data.TokenFile.ValueString()
+	}
+
+	if tokenFile != "" {
+		content, err := os.ReadFile(tokenFile)
+		if err != nil {
+			diags.AddAttributeError(
+				root.AtName("token_file"),
+				"Invalid EDC Token File",
+				"The provider cannot read the EDC API token file: "+err.Error(),
+			)
+		} else {
+			overrideIfSet(&token, strings.TrimSpace(string(content)))
+		}
+	}
 
 	if !data.Token.IsNull() {
 		token = data.Token.ValueString()
 	}
 
-	if !data.Addresses.ControlEndpoint.IsNull() {
-		controlAddress = data.Addresses.ControlEndpoint.ValueString()
+	if !data.TokenEnv.IsNull() {
+		token = os.Getenv(data.TokenEnv.ValueString())
+	}
+
+	if !addresses.ControlEndpoint.IsNull() {
+		controlAddress = addresses.ControlEndpoint.ValueString()
 	}
 
-	if !data.Addresses.PublicEndpoint.IsNull() {
-		publicAddress = data.Addresses.ProtocolEndpoint.ValueString()
+	if !addresses.PublicEndpoint.IsNull() {
+		publicAddress = addresses.PublicEndpoint.ValueString()
 	}
 
-	if !data.Addresses.ManagementEndpoint.IsNull() {
-		managementAddress = data.Addresses.ManagementEndpoint.ValueString()
+	if !addresses.ManagementEndpoint.IsNull() {
+		managementAddress = addresses.ManagementEndpoint.ValueString()
 	}
 
-	if !data.Addresses.ProtocolEndpoint.IsNull() {
-		protocolAddress = data.Addresses.ProtocolEndpoint.ValueString()
+	if !addresses.ProtocolEndpoint.IsNull() {
+		protocolAddress = addresses.ProtocolEndpoint.ValueString()
 	}
 
-	if !data.Addresses.DefaultEndpoint.IsNull() {
-		defaultAddress = data.Addresses.DefaultEndpoint.ValueString()
+	if !addresses.DefaultEndpoint.IsNull() {
+		defaultAddress = addresses.DefaultEndpoint.ValueString()
 	}
 
-	if token == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("token"),
+	if token == "" && data.TokenCommand.IsNull() {
+		diags.AddAttributeError(
+			root.AtName("token"),
 			"Missing EDC Token",
 			"The provider cannot create the EDC API client as there is a missing or empty value for the EDC API token. "+
-				"Set the token value in the configuration or use the EDC_TOKEN environment variable. "+
+				"Set the token value in the configuration, point token_file, token_command or token_env to it, or use the EDC_TOKEN environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -220,8 +613,8 @@ func validateProviderOptions(data EDCProviderModel, resp *provider.ConfigureResp
 	for i := 0; i < v.NumField(); i++ {
 		if v.Field(i).Elem().String() == "" {
 			fieldName := typeOfAddresses.Field(i).Name
-			resp.Diagnostics.AddAttributeError(
-				path.Root(fieldName),
+			diags.AddAttributeError(
+				root.AtName(fieldName),
 				fmt.Sprintf("Missing EDC %s Address", fieldName),
 				fmt.Sprintf("The provider cannot create the EDC API client as there is a missing or empty value for the EDC API %s. "+
 					"Set the %s value in the configuration or use the EDC_%s environment variable. "+
@@ -232,6 +625,12 @@ func validateProviderOptions(data EDCProviderModel, resp *provider.ConfigureResp
 	return token, edcAddresses
 }
 
+func overrideIfSet(target *string, value string) {
+	if value != "" {
+		*target = value
+	}
+}
+
 func (p *EDCProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewAssetsResource,
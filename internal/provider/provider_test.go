@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"os"
 	"strings"
 	"testing"
@@ -9,7 +10,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,6 +48,71 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"edc": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// newTestResourceConfig returns a configuration of the resource holding the
+// given attribute values, the other attributes being null.
+func newTestResourceConfig(r resource.Resource, values map[string]tftypes.Value) tfsdk.Config {
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	objectType, _ := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	return tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    newTestObjectValue(objectType, values),
+	}
+}
+
+// newTestObjectValue returns an object holding the given attribute values,
+// the other attributes being null.
+func newTestObjectValue(objectType tftypes.Object, values map[string]tftypes.Value) tftypes.Value {
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		} else {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+	return tftypes.NewValue(objectType, attributes)
+}
+
+// planTestResource plans the change of the resource of the given type from
+// the prior values to the proposed ones, through the provider server. The
+// proposed values are also the configuration, except the computed ones.
+func planTestResource(t *testing.T, typeName string, r resource.Resource, prior, proposed map[string]tftypes.Value, computed ...string) *tfprotov6.PlanResourceChangeResponse {
+	ctx := context.Background()
+
+	configured := make(map[string]tftypes.Value, len(proposed))
+	for name, value := range proposed {
+		configured[name] = value
+	}
+	for _, name := range computed {
+		delete(configured, name)
+	}
+
+	dynamicValue := func(values map[string]tftypes.Value) *tfprotov6.DynamicValue {
+		value := newTestResourceConfig(r, values).Raw
+		dv, err := tfprotov6.NewDynamicValue(value.Type(), value)
+		assert.NoError(t, err)
+		return &dv
+	}
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	assert.NoError(t, err)
+	_, err = server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	assert.NoError(t, err)
+
+	resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       dynamicValue(prior),
+		ProposedNewState: dynamicValue(proposed),
+		Config:           dynamicValue(configured),
+	})
+	assert.NoError(t, err)
+	return resp
+}
+
 func testAccPreCheck(t *testing.T) {
 	// You can add code here to run prior to any test case execution, for example assertions
 	// about the appropriate environment variables being set are common to see in a pre-check
@@ -59,6 +129,16 @@ func TestEDCProvider_Configure(t *testing.T) {
 	publicAddress := "http://localhost:29193/public"
 	controlAddress := "http://localhost:29193/control"
 	authToken := "dummyToken"
+	fileDefaultAddress := "http://localhost:9191/api"
+	fileManagementAddress := "http://localhost:9193/api/v1/data"
+	fileProtocolAddress := "http://localhost:9194/api/v1/ids"
+	filePublicAddress := "http://localhost:9291/public"
+	fileControlAddress := "http://localhost:9192/control"
+	offsetDefaultAddress := "http://localhost:29191/api"
+	offsetManagementAddress := "http://localhost:29193/api/v1/data"
+	offsetProtocolAddress := "http://localhost:29194/api/v1/ids"
+	offsetPublicAddress := "http://localhost:29291/public"
+	offsetControlAddress := "http://localhost:29192/control"
 	tests := []struct {
 		name                           string
 		args                           args
@@ -92,6 +172,133 @@ func TestEDCProvider_Configure(t *testing.T) {
 			diagnosticsPathErrorAttributes: []string{},
 			expectedError:                  false,
 		},
+		{
+			name: "configuration file",
+			args: args{
+				data: EDCProviderModel{
+					ConnectorConfigFile: types.StringValue("../../conf/provider-connector.config/configuration.properties"),
+					ConnectorHost:       types.StringValue("localhost"),
+				},
+			},
+			env:           map[string]string{},
+			expectedToken: "123456",
+			expectedEdcAddresses: edc.Addresses{
+				Default:    &fileDefaultAddress,
+				Management: &fileManagementAddress,
+				Protocol:   &fileProtocolAddress,
+				Public:     &filePublicAddress,
+				Control:    &fileControlAddress,
+			},
+			diagnosticsPathErrorAttributes: []string{},
+			expectedError:                  false,
+		},
+		{
+			name: "configuration file overridden by environment and addresses",
+			args: args{
+				data: EDCProviderModel{
+					ConnectorConfigFile: types.StringValue("../../conf/provider-connector.config/configuration.properties"),
+					Addresses: &Addresses{
+						ManagementEndpoint: types.StringValue(managementAddress),
+					},
+				},
+			},
+			env: map[string]string{
+				"EDC_TOKEN":      authToken,
+				"EDC_PUBLIC":     publicAddress,
+				"EDC_MANAGEMENT": "http://localhost:39193/api/v1/data",
+			},
+			expectedToken: authToken,
+			expectedEdcAddresses: edc.Addresses{
+				Default:    &fileDefaultAddress,
+				Management: &managementAddress,
+				Protocol:   &fileProtocolAddress,
+				Public:     &publicAddress,
+				Control:    &fileControlAddress,
+			},
+			diagnosticsPathErrorAttributes: []string{},
+			expectedError:                  false,
+		},
+		{
+			name: "configuration file with port offset",
+			args: args{
+				data: EDCProviderModel{
+					ConnectorConfigFile: types.StringValue("../../conf/provider-connector.config/configuration.properties"),
+				},
+			},
+			env: map[string]string{
+				"EDC_CONNECTOR_PORT_OFFSET": "20000",
+			},
+			expectedToken: "123456",
+			expectedEdcAddresses: edc.Addresses{
+				Default:    &offsetDefaultAddress,
+				Management: &offsetManagementAddress,
+				Protocol:   &offsetProtocolAddress,
+				Public:     &offsetPublicAddress,
+				Control:    &offsetControlAddress,
+			},
+			diagnosticsPathErrorAttributes: []string{},
+			expectedError:                  false,
+		},
+		{
+			name: "invalid port offset",
+			args: args{
+				data: EDCProviderModel{
+					ConnectorConfigFile: types.StringValue("../../conf/provider-connector.config/configuration.properties"),
+				},
+			},
+			env: map[string]string{
+				"EDC_CONNECTOR_PORT_OFFSET": "twenty",
+			},
+			expectedEdcAddresses:           edc.Addresses{},
+			diagnosticsPathErrorAttributes: []string{"connector_port_offset"},
+			expectedError:                  true,
+		},
+		{
+			name: "addresses",
+			args: args{
+				data: EDCProviderModel{
+					Token: types.StringValue(authToken),
+					Addresses: &Addresses{
+						DefaultEndpoint:    types.StringValue(defaultAddress),
+						ManagementEndpoint: types.StringValue(managementAddress),
+						ProtocolEndpoint:   types.StringValue(protocolAddress),
+						PublicEndpoint:     types.StringValue(publicAddress),
+						ControlEndpoint:    types.StringValue(controlAddress),
+					},
+				},
+			},
+			env:           map[string]string{},
+			expectedToken: authToken,
+			expectedEdcAddresses: edc.Addresses{
+				Default:    &defaultAddress,
+				Management: &managementAddress,
+				Protocol:   &protocolAddress,
+				Public:     &publicAddress,
+				Control:    &controlAddress,
+			},
+			diagnosticsPathErrorAttributes: []string{},
+			expectedError:                  false,
+		},
+		{
+			name: "missing configuration file",
+			args: args{
+				data: EDCProviderModel{
+					ConnectorConfigFile: types.StringValue("does-not-exist.properties"),
+				},
+			},
+			env: map[string]string{
+				"EDC_TOKEN":      authToken,
+				"EDC_DEFAULT":    defaultAddress,
+				"EDC_MANAGEMENT": managementAddress,
+				"EDC_PROTOCOL":   protocolAddress,
+				"EDC_PUBLIC":     publicAddress,
+				"EDC_CONTROL":    controlAddress,
+			},
+			expectedToken:                  authToken,
+			expectedEdcAddresses:           edc.Addresses{},
+			diagnosticsPathErrorAttributes: []string{"connector_config_file"},
+			expectedError:                  true,
+		},
 		{
 			name: "invalid configuration",
 			args: args{
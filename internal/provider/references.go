@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Kinds of the objects referenced by contract definitions.
+const (
+	policyReference = "policy"
+	assetReference  = "asset"
+)
+
+// reference is the identifier of a policy or an asset held by an attribute.
+type reference struct {
+	path path.Path
+	kind string
+	id   string
+}
+
+// recordPlannedId records the identifier planned for a policy or an asset, so
+// that the resources planned after it, which depend on it, may reference it
+// before it is created. Unknown identifiers are ignored.
+func (c *EDCConnectors) recordPlannedId(ctx context.Context, plan tfsdk.Plan, kind string, idPath path.Path) {
+	if c == nil || plan.Raw.IsNull() {
+		return
+	}
+
+	var id, name types.String
+	if plan.GetAttribute(ctx, idPath, &id).HasError() || plan.GetAttribute(ctx, path.Root("connector"), &name).HasError() {
+		return
+	}
+	if id.IsNull() || id.IsUnknown() || name.IsUnknown() {
+		return
+	}
+
+	managed, diags := c.managed(name)
+	if diags.HasError() {
+		return
+	}
+
+	managed.mu.Lock()
+	defer managed.mu.Unlock()
+
+	if managed.planned == nil {
+		managed.planned = map[string]map[string]bool{}
+	}
+	if managed.planned[kind] == nil {
+		managed.planned[kind] = map[string]bool{}
+	}
+	managed.planned[kind][id.ValueString()] = true
+}
+
+// isPlanned reports whether a policy or an asset is planned on the connector
+// selected by the given name.
+func (c *EDCConnectors) isPlanned(name types.String, kind, id string) bool {
+	managed, diags := c.managed(name)
+	if diags.HasError() {
+		return false
+	}
+
+	managed.mu.Lock()
+	defer managed.mu.Unlock()
+
+	return managed.planned[kind][id]
+}
+
+// checkReferences reports the references to policies and assets that do not
+// exist on the connector, unless they are planned. References that cannot be
+// checked are reported as warnings.
+func checkReferences(connector *EDCConnector, references []reference, planned func(kind, id string) bool, diags *diag.Diagnostics) {
+	for _, ref := range references {
+		if planned(ref.kind, ref.id) {
+			continue
+		}
+
+		var err error
+		summary := "Unknown Policy Reference"
+		if ref.kind == policyReference {
+			_, err = connector.Policies.GetPolicy(ref.id)
+		} else {
+			_, err = connector.Assets.GetAsset(ref.id)
+			summary = "Unknown Asset Reference"
+		}
+
+		switch {
+		case err == nil:
+		case isNotFoundError(err):
+			diags.AddAttributeError(
+				ref.path,
+				summary,
+				fmt.Sprintf("The %s %q does not exist on the connector, and is not planned by a resource this one depends on. "+
+					"Check the identifier, or reference the attribute of the resource creating the %s.", ref.kind, ref.id, ref.kind),
+			)
+		default:
+			diags.AddAttributeWarning(
+				ref.path,
+				"Unable to Check Reference",
+				fmt.Sprintf("The provider could not check that the %s %q exists on the connector.\n\nError: %s", ref.kind, ref.id, err),
+			)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_checkReferences(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]; {
+		case strings.HasPrefix(id, "missing"):
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`[{"message": "Object with ID ` + id + ` was not found", "type": "ObjectNotFound"}]`))
+		case id == "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		case strings.Contains(r.URL.Path, "/policydefinitions/"):
+			_, _ = w.Write([]byte(`{"@id": "` + id + `", "policy": {"@type": "odrl:Set"}}`))
+		default:
+			_, _ = w.Write([]byte(`{"@id": "` + id + `", "properties": {}}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	connector := newTestConnector(t, server.URL, managementAPIV3)
+
+	references := []reference{
+		{path: path.Root("access_policy_id"), kind: policyReference, id: "access"},
+		{path: path.Root("contract_policy_id"), kind: policyReference, id: "missing-policy"},
+		{path: path.Root("criteria").AtListIndex(0).AtName("operand_right"), kind: assetReference, id: "asset-1"},
+		{path: path.Root("criteria").AtListIndex(1).AtName("operand_right"), kind: assetReference, id: "missing-asset"},
+		{path: path.Root("criteria").AtListIndex(2).AtName("operand_right"), kind: assetReference, id: "missing-planned"},
+		{path: path.Root("criteria").AtListIndex(3).AtName("operand_right"), kind: assetReference, id: "broken"},
+	}
+	planned := func(kind, id string) bool { return kind == assetReference && id == "missing-planned" }
+
+	var diags diag.Diagnostics
+	checkReferences(connector, references, planned, &diags)
+	assert.Equal(t, []string{`contract_policy_id`, `criteria[1].operand_right`}, getErrorPaths(diags.Errors()))
+	assert.Equal(t, diag.Diagnostics{
+		diag.NewAttributeErrorDiagnostic(
+			path.Root("contract_policy_id"),
+			"Unknown Policy Reference",
+			`The policy "missing-policy" does not exist on the connector, and is not planned by a resource this one depends on. `+
+				"Check the identifier, or reference the attribute of the resource creating the policy.",
+		),
+	}, diags.Errors()[:1])
+	assert.Equal(t, "Unknown Asset Reference", diags.Errors()[1].Summary())
+	assert.Len(t, diags.Warnings(), 1)
+	assert.Equal(t, "Unable to Check Reference", diags.Warnings()[0].Summary())
+}
+
+func Test_contractDefinitionReferences(t *testing.T) {
+	criterionType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"operand_left":       tftypes.String,
+		"operator":           tftypes.String,
+		"operand_right":      tftypes.String,
+		"operand_right_list": tftypes.List{ElementType: tftypes.String},
+	}}
+	newCriterion := func(operandLeft, operator string, operandRight tftypes.Value, operandRightList tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(criterionType, map[string]tftypes.Value{
+			"operand_left":       tftypes.NewValue(tftypes.String, operandLeft),
+			"operator":           tftypes.NewValue(tftypes.String, operator),
+			"operand_right":      operandRight,
+			"operand_right_list": operandRightList,
+		})
+	}
+	nullList := tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil)
+
+	config := newTestResourceConfig(NewContractDefinitionResource(), map[string]tftypes.Value{
+		"access_policy_id":   tftypes.NewValue(tftypes.String, "access"),
+		"contract_policy_id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"criteria": tftypes.NewValue(tftypes.List{ElementType: criterionType}, []tftypes.Value{
+			newCriterion("asset:prop:id", "=", tftypes.NewValue(tftypes.String, "asset-1"), nullList),
+			newCriterion("edc:id", "in", tftypes.NewValue(tftypes.String, nil), tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "asset-2"),
+				tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			})),
+			newCriterion("asset:prop:id", "like", tftypes.NewValue(tftypes.String, "asset-%"), nullList),
+			newCriterion("asset:prop:name", "=", tftypes.NewValue(tftypes.String, "Asset"), nullList),
+		}),
+	})
+	plan := tfsdk.Plan{Schema: config.Schema, Raw: config.Raw}
+
+	references, diags := contractDefinitionReferences(context.Background(), plan, defaultJSONLDPrefixes)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, []reference{
+		{path: path.Root("access_policy_id"), kind: policyReference, id: "access"},
+		{path: path.Root("criteria").AtListIndex(0).AtName("operand_right"), kind: assetReference, id: "asset-1"},
+		{path: path.Root("criteria").AtListIndex(1).AtName("operand_right_list").AtListIndex(0), kind: assetReference, id: "asset-2"},
+	}, references)
+}
+
+func TestEDCConnectors_recordPlannedId(t *testing.T) {
+	connectors := &EDCConnectors{
+		defaultConnector: &managedConnector{},
+		named:            map[string]*managedConnector{"provider": {}},
+	}
+	config := newTestResourceConfig(NewAssetsResource(), map[string]tftypes.Value{
+		"asset_id":  tftypes.NewValue(tftypes.String, "asset-1"),
+		"connector": tftypes.NewValue(tftypes.String, "provider"),
+	})
+
+	connectors.recordPlannedId(context.Background(), tfsdk.Plan{Schema: config.Schema, Raw: config.Raw}, assetReference, path.Root("asset_id"))
+	assert.True(t, connectors.isPlanned(types.StringValue("provider"), assetReference, "asset-1"))
+	assert.False(t, connectors.isPlanned(types.StringValue("provider"), policyReference, "asset-1"))
+	assert.False(t, connectors.isPlanned(types.StringNull(), assetReference, "asset-1"))
+}
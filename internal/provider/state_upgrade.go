@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// rawStateUpgrader returns a state upgrader applying upgrade to the JSON
+// document of the prior state. The upgraded document must conform to the
+// current schema of the resource.
+func rawStateUpgrader(upgrade func(state map[string]interface{}) error) resource.StateUpgrader {
+	return resource.StateUpgrader{
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			if req.RawState == nil {
+				resp.Diagnostics.AddError("Unable to Upgrade Resource State", "The prior state is missing.")
+				return
+			}
+
+			var state map[string]interface{}
+			if err := json.Unmarshal(req.RawState.JSON, &state); err != nil {
+				resp.Diagnostics.AddError("Unable to Upgrade Resource State", "Unable to read the prior state: "+err.Error())
+				return
+			}
+
+			if err := upgrade(state); err != nil {
+				resp.Diagnostics.AddError("Unable to Upgrade Resource State", err.Error())
+				return
+			}
+
+			content, err := json.Marshal(state)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to Upgrade Resource State", "Unable to write the upgraded state: "+err.Error())
+				return
+			}
+			resp.DynamicValue = &tfprotov6.DynamicValue{JSON: content}
+		},
+	}
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// tokenExpiryLeeway is the delay before the expiry of a token from which it
+// is considered expired, so that it does not expire during a request.
+const tokenExpiryLeeway = 30 * time.Second
+
+// tokenSource provides the token authenticating the provider against the
+// connector APIs. Tokens printed by a command are refreshed by running the
+// command again once they expire.
+type tokenSource struct {
+	command []string
+	token   string
+	// expiry is the zero time when the token does not expire.
+	expiry time.Time
+	now    func() time.Time
+}
+
+func newStaticTokenSource(token string) *tokenSource {
+	return &tokenSource{
+		token: token,
+		now:   time.Now,
+	}
+}
+
+// newCommandTokenSource returns a token source running the given command,
+// which is run once to make sure it works.
+func newCommandTokenSource(ctx context.Context, command []string) (*tokenSource, error) {
+	if len(command) == 0 || command[0] == "" {
+		return nil, fmt.Errorf("the token command is empty")
+	}
+
+	s := &tokenSource{
+		command: command,
+		now:     time.Now,
+	}
+	if err := s.refresh(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Expired reports whether the token must be refreshed before being used.
+func (s *tokenSource) Expired() bool {
+	if s.expiry.IsZero() {
+		return false
+	}
+	return !s.now().Add(tokenExpiryLeeway).Before(s.expiry)
+}
+
+// refresh runs the token command and stores the printed token. The command
+// either prints the token alone, or a JSON object with a "token" field and
+// an optional "expires_at" RFC 3339 timestamp or "expires_in" duration in
+// seconds. Without explicit expiry, the "exp" claim of JWT tokens is used.
+func (s *tokenSource) refresh(ctx context.Context) error {
+	if len(s.command) == 0 {
+		return nil
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.command[0], s.command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("token command %q failed: %w: %s", s.command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	token, expiry, err := parseTokenCommandOutput(stdout.Bytes(), s.now())
+	if err != nil {
+		return fmt.Errorf("token command %q: %w", s.command[0], err)
+	}
+
+	s.token = token
+	s.expiry = expiry
+	return nil
+}
+
+type tokenCommandOutput struct {
+	Token     string     `json:"token"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	ExpiresIn *int64     `json:"expires_in"`
+}
+
+func parseTokenCommandOutput(output []byte, now time.Time) (string, time.Time, error) {
+	trimmed := bytes.TrimSpace(output)
+
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		var parsed tokenCommandOutput
+		if err := json.Unmarshal(trimmed, &parsed); err != nil {
+			return "", time.Time{}, fmt.Errorf("unable to parse the JSON output: %w", err)
+		}
+		if parsed.Token == "" {
+			return "", time.Time{}, fmt.Errorf("the JSON output has no token field")
+		}
+
+		switch {
+		case parsed.ExpiresAt != nil:
+			return parsed.Token, *parsed.ExpiresAt, nil
+		case parsed.ExpiresIn != nil:
+			return parsed.Token, now.Add(time.Duration(*parsed.ExpiresIn) * time.Second), nil
+		default:
+			return parsed.Token, jwtExpiry(parsed.Token), nil
+		}
+	}
+
+	token := string(trimmed)
+	if token == "" {
+		return "", time.Time{}, fmt.Errorf("the output is empty")
+	}
+	return token, jwtExpiry(token), nil
+}
+
+// jwtExpiry returns the expiry of the token when it is a JWT holding an
+// "exp" claim, and the zero time otherwise. The signature is not verified.
+func jwtExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		Exp *json.Number `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == nil {
+		return time.Time{}
+	}
+
+	exp, err := claims.Exp.Float64()
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(int64(exp), 0)
+}
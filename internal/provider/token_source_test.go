@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseTokenCommandOutput(t *testing.T) {
+	now := time.Date(2023, 9, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := time.Date(2023, 9, 1, 13, 0, 0, 0, time.UTC)
+	jwt := "header." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"terraform","exp":1693573200}`)) + ".signature"
+
+	tests := []struct {
+		name           string
+		output         string
+		expectedToken  string
+		expectedExpiry time.Time
+		expectedError  bool
+	}{
+		{
+			name:          "plain token",
+			output:        "123456\n",
+			expectedToken: "123456",
+		},
+		{
+			name:           "JSON with expiry timestamp",
+			output:         `{"token": "123456", "expires_at": "2023-09-01T13:00:00Z"}`,
+			expectedToken:  "123456",
+			expectedExpiry: expiresAt,
+		},
+		{
+			name:           "JSON with expiry duration",
+			output:         `{"token": "123456", "expires_in": 3600}`,
+			expectedToken:  "123456",
+			expectedExpiry: expiresAt,
+		},
+		{
+			name:           "JWT expiry claim",
+			output:         jwt,
+			expectedToken:  jwt,
+			expectedExpiry: time.Unix(1693573200, 0),
+		},
+		{
+			name:          "JSON without token",
+			output:        `{"expires_in": 3600}`,
+			expectedError: true,
+		},
+		{
+			name:          "invalid JSON",
+			output:        `{"token": `,
+			expectedError: true,
+		},
+		{
+			name:          "empty output",
+			output:        " \n",
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, expiry, err := parseTokenCommandOutput([]byte(tt.output), now)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedToken, token)
+			assert.True(t, tt.expectedExpiry.Equal(expiry), "expected expiry %s, got %s", tt.expectedExpiry, expiry)
+		})
+	}
+}
+
+func Test_tokenSource_Expired(t *testing.T) {
+	now := time.Date(2023, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expiry   time.Time
+		expected bool
+	}{
+		{
+			name:     "no expiry",
+			expected: false,
+		},
+		{
+			name:     "valid",
+			expiry:   now.Add(time.Hour),
+			expected: false,
+		},
+		{
+			name:     "within leeway",
+			expiry:   now.Add(tokenExpiryLeeway / 2),
+			expected: true,
+		},
+		{
+			name:     "expired",
+			expiry:   now.Add(-time.Minute),
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &tokenSource{
+				token:  "123456",
+				expiry: tt.expiry,
+				now:    func() time.Time { return now },
+			}
+
+			assert.Equal(t, tt.expected, source.Expired())
+		})
+	}
+}
+
+func Test_newCommandTokenSource(t *testing.T) {
+	source, err := newCommandTokenSource(context.Background(), []string{"echo", "123456"})
+	assert.NoError(t, err)
+	assert.Equal(t, "123456", source.token)
+	assert.False(t, source.Expired())
+
+	_, err = newCommandTokenSource(context.Background(), []string{"false"})
+	assert.Error(t, err)
+
+	_, err = newCommandTokenSource(context.Background(), nil)
+	assert.Error(t, err)
+}